@@ -0,0 +1,45 @@
+package ads
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/ads"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameAdvertisers = "advertisers"
+
+func NewAdvertisersCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName}
+	cmd := &cobra.Command{
+		Use:   CommandNameAdvertisers,
+		Short: "Retrieve a list of advertisers using your activity or information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			customAudience, err := cmd.Flags().GetBool(instagram.FlagCustomAudience)
+			if err != nil {
+				return err
+			}
+			remarketing, err := cmd.Flags().GetBool(instagram.FlagRemarketing)
+			if err != nil {
+				return err
+			}
+			advertisers, err := ads.NewHandler(instagram.NewFs(cmd.Context())).Advertisers(opts, customAudience, remarketing)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*advertisers)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldName, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagCustomAudience, false, `only show advertisers that uploaded a customer list containing you`)
+	cmd.Flags().Bool(instagram.FlagRemarketing, false, `only show advertisers that added you to a remarketing list`)
+	return cmd
+}
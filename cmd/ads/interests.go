@@ -0,0 +1,35 @@
+package ads
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/ads"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameInterests = "interests"
+
+func NewInterestsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName}
+	cmd := &cobra.Command{
+		Use:   CommandNameInterests,
+		Short: "Retrieve a list of interests inferred from your activity",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			interests, err := ads.NewHandler(instagram.NewFs(cmd.Context())).Interests(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*interests)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldName, sortByFields...)
+	return cmd
+}
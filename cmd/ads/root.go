@@ -0,0 +1,25 @@
+package ads
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameAds = "ads"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameAds),
+		Short: "Instagram ads information operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewAdvertisersCommand(),
+		NewInterestsCommand(),
+	)
+	return cmd
+}
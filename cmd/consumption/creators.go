@@ -0,0 +1,40 @@
+package consumption
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/consumption"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameCreators = "creators"
+
+func NewCreatorsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameCreators,
+		Short: "Retrieve a list of creators whose posts, videos, ads and suggestions you have seen",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			notFollowing, err := cmd.Flags().GetBool(instagram.FlagNotFollowing)
+			if err != nil {
+				return err
+			}
+			creators, err := consumption.NewHandler(instagram.NewFs(cmd.Context())).Creators(opts, notFollowing)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*creators)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldCount, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagNotFollowing, false, `only show creators you do not follow`)
+	return cmd
+}
@@ -0,0 +1,35 @@
+package consumption
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/consumption"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameDaily = "daily"
+
+func NewDailyCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameDaily,
+		Short: "Retrieve the number of posts, videos, ads and suggested profiles you have seen per day",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			daily, err := consumption.NewHandler(instagram.NewFs(cmd.Context())).Daily(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*daily)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
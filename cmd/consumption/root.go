@@ -0,0 +1,25 @@
+package consumption
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameConsumption = "consumption"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameConsumption),
+		Short: "Instagram content consumption operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewCreatorsCommand(),
+		NewDailyCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,45 @@
+package contacts
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/contacts"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameContacts = "contacts"
+
+func NewRootCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldName}
+	cmd := &cobra.Command{
+		Use:   CommandNameContacts,
+		Short: "Retrieve a list of synced contacts and the followers or following accounts that match their names",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			matched, err := cmd.Flags().GetBool(instagram.FlagMatched)
+			if err != nil {
+				return err
+			}
+			reveal, err := cmd.Flags().GetBool(instagram.FlagReveal)
+			if err != nil {
+				return err
+			}
+			list, err := contacts.NewHandler(instagram.NewFs(cmd.Context())).Contacts(opts, matched, reveal)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*list)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldName, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagMatched, false, `only show contacts that match a follower or following account`)
+	cmd.Flags().Bool(instagram.FlagReveal, false, `show contact information such as phone numbers instead of redacting it`)
+	return cmd
+}
@@ -9,18 +9,19 @@ import (
 const CommandNameFollowers = "followers"
 
 func NewFollowersCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
 	cmd := &cobra.Command{
 		Use:   CommandNameFollowers,
 		Short: "Retrieve a list of users who follow you",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts, err := instagram.NewOptions(cmd.Flags())
+			opts, err := instagram.NewOptions(cmd)
 			if err != nil {
 				return err
 			}
-			if err = opts.Validate(); err != nil {
+			if err = opts.Validate(sortByFields...); err != nil {
 				return err
 			}
-			followers, err := followdata.NewHandler().Followers(opts)
+			followers, err := followdata.NewHandler(instagram.NewFs(cmd.Context())).Followers(cmd.Context(), opts)
 			if err != nil {
 				return err
 			}
@@ -29,6 +30,6 @@ func NewFollowersCommand() *cobra.Command {
 		},
 		DisableAutoGenTag: true,
 	}
-	addCommonFlags(cmd)
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
 	return cmd
 }
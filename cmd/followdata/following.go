@@ -9,18 +9,19 @@ import (
 const CommandNameFollowing = "following"
 
 func NewFollowingCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
 	cmd := &cobra.Command{
 		Use:   CommandNameFollowing,
 		Short: "Retrieve a list of users who you follow",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts, err := instagram.NewOptions(cmd.Flags())
+			opts, err := instagram.NewOptions(cmd)
 			if err != nil {
 				return err
 			}
-			if err = opts.Validate(); err != nil {
+			if err = opts.Validate(sortByFields...); err != nil {
 				return err
 			}
-			following, err := followdata.NewHandler().Following(opts)
+			following, err := followdata.NewHandler(instagram.NewFs(cmd.Context())).Following(cmd.Context(), opts)
 			if err != nil {
 				return err
 			}
@@ -29,6 +30,6 @@ func NewFollowingCommand() *cobra.Command {
 		},
 		DisableAutoGenTag: true,
 	}
-	addCommonFlags(cmd)
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
 	return cmd
 }
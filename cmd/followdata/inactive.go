@@ -0,0 +1,40 @@
+package followdata
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameInactive = "inactive"
+
+func NewInactiveCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameInactive,
+		Short: "Retrieve a list of users you follow but never liked, commented on, messaged or searched for",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			since, err := cmd.Flags().GetString(instagram.FlagSince)
+			if err != nil {
+				return err
+			}
+			inactive, err := followdata.NewHandler(instagram.NewFs(cmd.Context())).Inactive(cmd.Context(), opts, since)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*inactive)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().String(instagram.FlagSince, "", `only count interactions on or after this date (YYYY-MM-DD), omit this flag to consider all history`)
+	return cmd
+}
@@ -3,7 +3,6 @@ package followdata
 import (
 	"fmt"
 
-	"github.com/cecobask/instagram-insights/pkg/instagram"
 	"github.com/spf13/cobra"
 )
 
@@ -22,14 +21,8 @@ func NewRootCommand() *cobra.Command {
 	cmd.AddCommand(
 		NewFollowersCommand(),
 		NewFollowingCommand(),
+		NewInactiveCommand(),
 		NewUnfollowersCommand(),
 	)
 	return cmd
 }
-
-func addCommonFlags(cmd *cobra.Command) {
-	cmd.Flags().Int(instagram.FlagLimit, instagram.Unlimited, `max results to display, omit this flag or set to 0 for unlimited`)
-	cmd.Flags().String(instagram.FlagOrder, instagram.OrderDesc, `order direction ("asc", "desc")`)
-	cmd.Flags().String(instagram.FlagOutput, instagram.OutputTable, `output format ("json", "table", "yaml")`)
-	cmd.Flags().String(instagram.FlagSortBy, instagram.FieldTimestamp, `sort by field ("timestamp", "username")`)
-}
@@ -9,18 +9,19 @@ import (
 const CommandNameUnfollowers = "unfollowers"
 
 func NewUnfollowersCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
 	cmd := &cobra.Command{
 		Use:   CommandNameUnfollowers,
 		Short: "Retrieve a list of users who are not following you back",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts, err := instagram.NewOptions(cmd.Flags())
+			opts, err := instagram.NewOptions(cmd)
 			if err != nil {
 				return err
 			}
-			if err = opts.Validate(); err != nil {
+			if err = opts.Validate(sortByFields...); err != nil {
 				return err
 			}
-			unfollowers, err := followdata.NewHandler().Unfollowers(opts)
+			unfollowers, err := followdata.NewHandler(instagram.NewFs(cmd.Context())).Unfollowers(cmd.Context(), opts)
 			if err != nil {
 				return err
 			}
@@ -29,6 +30,6 @@ func NewUnfollowersCommand() *cobra.Command {
 		},
 		DisableAutoGenTag: true,
 	}
-	addCommonFlags(cmd)
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
 	return cmd
 }
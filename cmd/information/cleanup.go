@@ -1,6 +1,7 @@
 package information
 
 import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
 	"github.com/cecobask/instagram-insights/pkg/instagram/information"
 	"github.com/spf13/cobra"
 )
@@ -12,7 +13,7 @@ func NewCleanupCommand() *cobra.Command {
 		Use:   CommandNameCleanup,
 		Short: "Cleanup local Instagram information",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return information.NewHandler().Cleanup()
+			return information.NewHandler(instagram.NewFs(cmd.Context())).Cleanup()
 		},
 		DisableAutoGenTag: true,
 	}
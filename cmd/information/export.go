@@ -0,0 +1,28 @@
+package information
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/information"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameExport = "export"
+
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CommandNameExport,
+		Example: "instagram information export --sqlite instagram.db",
+		Short:   "Export Instagram information into a SQLite database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sqlitePath, err := cmd.Flags().GetString(instagram.FlagSqlite)
+			if err != nil {
+				return err
+			}
+			return information.NewHandler(instagram.NewFs(cmd.Context())).Export(sqlitePath)
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.Flags().String(instagram.FlagSqlite, "", `path of the SQLite database to create, an existing file is replaced`)
+	_ = cmd.MarkFlagRequired(instagram.FlagSqlite)
+	return cmd
+}
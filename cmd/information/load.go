@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cecobask/instagram-insights/pkg/instagram"
 	"github.com/cecobask/instagram-insights/pkg/instagram/information"
 	"github.com/spf13/cobra"
 )
@@ -12,23 +13,28 @@ const CommandNameLoad = "load"
 
 func NewLoadCommand() *cobra.Command {
 	return &cobra.Command{
-		Use: CommandNameLoad + " <source>",
+		Use: CommandNameLoad + " [source]",
 		Example: func() string {
 			examples := []string{
 				"instagram information load https://drive.google.com/file/d/xyz",
 				"instagram information load file:///home/username/Desktop/instagram_data.zip",
+				"INSTAGRAM_SOURCE=https://drive.google.com/file/d/xyz instagram information load",
 			}
 			return strings.Join(examples, "\n")
 		}(),
 		Short: "Load Instagram information",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) > 1 || len(args) == 0 && instagram.ConfigFromContext(cmd.Context()).Source == "" {
 				return fmt.Errorf("must provide exactly one location")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return information.NewHandler().Load(args[0])
+			source := instagram.ConfigFromContext(cmd.Context()).Source
+			if len(args) == 1 {
+				source = args[0]
+			}
+			return information.NewHandler(instagram.NewFs(cmd.Context())).Load(source)
 		},
 		DisableAutoGenTag: true,
 	}
@@ -21,6 +21,7 @@ func NewRootCommand() *cobra.Command {
 	cmd.AddCommand(
 		NewLoadCommand(),
 		NewCleanupCommand(),
+		NewExportCommand(),
 	)
 	return cmd
 }
@@ -0,0 +1,48 @@
+package insights
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/heatmap"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameHeatmap = "heatmap"
+
+func NewHeatmapCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameHeatmap,
+		Short: "Retrieve a weekday by hour grid of activity counts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			categories, err := cmd.Flags().GetString(instagram.FlagCategory)
+			if err != nil {
+				return err
+			}
+			timezone, err := cmd.Flags().GetString(instagram.FlagTimezone)
+			if err != nil {
+				return err
+			}
+			grid, err := heatmap.NewHandler(instagram.NewFs(cmd.Context())).Heatmap(opts, categories, timezone)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*grid)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().String(instagram.FlagCategory, "", fmt.Sprintf(`comma-separated event categories to count ("%s"), omit this flag for all events`, strings.Join(heatmap.Categories(), `", "`)))
+	cmd.Flags().String(instagram.FlagTimezone, "Local", `IANA time zone used to bucket events, e.g. "Europe/Dublin"`)
+	return cmd
+}
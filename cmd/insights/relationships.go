@@ -0,0 +1,40 @@
+package insights
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameRelationships = "relationships"
+
+func NewRelationshipsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldScore, instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameRelationships,
+		Short: "Rank accounts by a weighted engagement score from closest to no interaction",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			weights, err := cmd.Flags().GetString(instagram.FlagWeights)
+			if err != nil {
+				return err
+			}
+			relationships, err := followdata.NewHandler(instagram.NewFs(cmd.Context())).Relationships(cmd.Context(), opts, weights)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*relationships)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldScore, sortByFields...)
+	cmd.Flags().String(instagram.FlagWeights, followdata.DefaultWeights, `comma-separated engagement weights overriding the defaults ("comments", "follower", "following", "likes", "messages", "stories")`)
+	return cmd
+}
@@ -0,0 +1,25 @@
+package insights
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameInsights = "insights"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameInsights),
+		Short: "Instagram cross-category insights operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewHeatmapCommand(),
+		NewRelationshipsCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,35 @@
+package offsite
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/offsite"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameApps = "apps"
+
+func NewAppsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldName, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameApps,
+		Short: "Retrieve a list of apps and websites that shared your activity, with event counts and date ranges",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			apps, err := offsite.NewHandler(instagram.NewFs(cmd.Context())).Apps(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*apps)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldCount, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,35 @@
+package offsite
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/offsite"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameLocations = "locations"
+
+func NewLocationsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName}
+	cmd := &cobra.Command{
+		Use:   CommandNameLocations,
+		Short: "Retrieve a list of locations Meta inferred about you",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			locations, err := offsite.NewHandler(instagram.NewFs(cmd.Context())).Locations(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*locations)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldName, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,25 @@
+package offsite
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameOffsite = "offsite"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameOffsite),
+		Short: "Instagram off-platform activity operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewAppsCommand(),
+		NewLocationsCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/profile"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameHistory = "history"
+
+func NewHistoryCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameHistory,
+		Short: "Retrieve a timeline of changes to your username, bio, email and other profile fields",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			reveal, err := cmd.Flags().GetBool(instagram.FlagReveal)
+			if err != nil {
+				return err
+			}
+			history, err := profile.NewHandler(instagram.NewFs(cmd.Context())).History(opts, reveal)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*history)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagReveal, false, `show contact fields such as email and phone number instead of redacting them`)
+	return cmd
+}
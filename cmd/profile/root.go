@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameProfile = "profile"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameProfile),
+		Short: "Instagram profile operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewHistoryCommand(),
+		NewShowCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/profile"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameShow = "show"
+
+func NewShowCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName}
+	cmd := &cobra.Command{
+		Use:   CommandNameShow,
+		Short: "Retrieve a summary of your current profile and account metadata",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			reveal, err := cmd.Flags().GetBool(instagram.FlagReveal)
+			if err != nil {
+				return err
+			}
+			summary, err := profile.NewHandler(instagram.NewFs(cmd.Context())).Show(opts, reveal)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*summary)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldName, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagReveal, false, `show contact fields such as email and phone number instead of redacting them`)
+	return cmd
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/report"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameReport = "report"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CommandNameReport,
+		Example: "instagram report --html report.html",
+		Short:   "Generate a self-contained HTML report of followers, following, unfollowers and growth charts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			htmlPath, err := cmd.Flags().GetString(instagram.FlagHtml)
+			if err != nil {
+				return err
+			}
+			return report.NewHandler(instagram.NewFs(cmd.Context())).Report(htmlPath)
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.Flags().String(instagram.FlagHtml, "", `path of the HTML file to create, an existing file is replaced`)
+	_ = cmd.MarkFlagRequired(instagram.FlagHtml)
+	return cmd
+}
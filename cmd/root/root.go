@@ -4,8 +4,23 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cecobask/instagram-insights/cmd/ads"
+	"github.com/cecobask/instagram-insights/cmd/consumption"
+	"github.com/cecobask/instagram-insights/cmd/contacts"
 	"github.com/cecobask/instagram-insights/cmd/followdata"
 	"github.com/cecobask/instagram-insights/cmd/information"
+	"github.com/cecobask/instagram-insights/cmd/insights"
+	"github.com/cecobask/instagram-insights/cmd/offsite"
+	"github.com/cecobask/instagram-insights/cmd/profile"
+	"github.com/cecobask/instagram-insights/cmd/report"
+	"github.com/cecobask/instagram-insights/cmd/saved"
+	"github.com/cecobask/instagram-insights/cmd/search"
+	"github.com/cecobask/instagram-insights/cmd/searches"
+	"github.com/cecobask/instagram-insights/cmd/security"
+	"github.com/cecobask/instagram-insights/cmd/serve"
+	"github.com/cecobask/instagram-insights/cmd/stories"
+	"github.com/cecobask/instagram-insights/cmd/timeline"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +31,14 @@ func NewRootCommand() *cobra.Command {
 		Use:     fmt.Sprintf("%s [command]", CommandNameInstagram),
 		Aliases: []string{"ig"},
 		Short:   "Instagram Insights CLI",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			config, err := instagram.NewConfig(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			cmd.SetContext(instagram.WithConfig(cmd.Context(), config))
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -25,11 +48,27 @@ func NewRootCommand() *cobra.Command {
 		SilenceUsage:      true,
 		DisableAutoGenTag: true,
 	}
+	cmd.PersistentFlags().String(instagram.FlagConfig, "", fmt.Sprintf("config file path (default $XDG_CONFIG_HOME/%s/%s)", instagram.ConfigDirName, instagram.ConfigFileName))
+	cmd.PersistentFlags().String(instagram.FlagDataDir, "", "directory containing the Instagram data, defaults to the working directory")
 	cmd.SetOut(os.Stdout)
 	cmd.SetErr(os.Stderr)
 	cmd.AddCommand(
 		information.NewRootCommand(),
 		followdata.NewRootCommand(),
+		ads.NewRootCommand(),
+		consumption.NewRootCommand(),
+		contacts.NewRootCommand(),
+		insights.NewRootCommand(),
+		offsite.NewRootCommand(),
+		profile.NewRootCommand(),
+		report.NewRootCommand(),
+		saved.NewRootCommand(),
+		search.NewRootCommand(),
+		searches.NewRootCommand(),
+		security.NewRootCommand(),
+		serve.NewRootCommand(),
+		stories.NewRootCommand(),
+		timeline.NewRootCommand(),
 	)
 	cmd.SetHelpCommand(&cobra.Command{
 		Hidden: true,
@@ -0,0 +1,35 @@
+package saved
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/saved"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameCollections = "collections"
+
+func NewCollectionsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameCollections,
+		Short: "Retrieve a list of posts you saved, grouped by collection",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			collections, err := saved.NewHandler(instagram.NewFs(cmd.Context())).Collections(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*collections)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,35 @@
+package saved
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/saved"
+	"github.com/spf13/cobra"
+)
+
+const CommandNamePosts = "posts"
+
+func NewPostsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNamePosts,
+		Short: "Retrieve a list of posts you saved",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			posts, err := saved.NewHandler(instagram.NewFs(cmd.Context())).Posts(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*posts)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
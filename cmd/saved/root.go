@@ -0,0 +1,25 @@
+package saved
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameSaved = "saved"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameSaved),
+		Short: "Instagram saved posts operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewCollectionsCommand(),
+		NewPostsCommand(),
+	)
+	return cmd
+}
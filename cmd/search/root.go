@@ -0,0 +1,53 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/search"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameSearch = "search"
+
+func NewRootCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameSearch + " <query>",
+		Short: "Search captions, comments, messages, searches and follow lists for a word or username",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("must provide exactly one query")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			regex, err := cmd.Flags().GetBool(instagram.FlagRegex)
+			if err != nil {
+				return err
+			}
+			ignoreCase, err := cmd.Flags().GetBool(instagram.FlagIgnoreCase)
+			if err != nil {
+				return err
+			}
+			results, err := search.NewHandler(instagram.NewFs(cmd.Context())).Search(opts, args[0], regex, ignoreCase)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*results)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagRegex, false, `treat the query as a regular expression`)
+	cmd.Flags().Bool(instagram.FlagIgnoreCase, false, `match the query case-insensitively`)
+	return cmd
+}
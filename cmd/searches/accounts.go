@@ -0,0 +1,40 @@
+package searches
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/searches"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameAccounts = "accounts"
+
+func NewAccountsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldName, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameAccounts,
+		Short: "Retrieve a list of accounts you searched for and whether you follow them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			notFollowing, err := cmd.Flags().GetBool(instagram.FlagNotFollowing)
+			if err != nil {
+				return err
+			}
+			accounts, err := searches.NewHandler(instagram.NewFs(cmd.Context())).Accounts(opts, notFollowing)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*accounts)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldCount, sortByFields...)
+	cmd.Flags().Bool(instagram.FlagNotFollowing, false, `only show accounts you searched for but never followed`)
+	return cmd
+}
@@ -0,0 +1,35 @@
+package searches
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/searches"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameHistory = "history"
+
+func NewHistoryCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldName, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameHistory,
+		Short: "Retrieve a chronological list of your searches",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			history, err := searches.NewHandler(instagram.NewFs(cmd.Context())).History(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*history)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,26 @@
+package searches
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameSearches = "searches"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameSearches),
+		Short: "Instagram search history operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewAccountsCommand(),
+		NewHistoryCommand(),
+		NewTopCommand(),
+	)
+	return cmd
+}
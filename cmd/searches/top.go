@@ -0,0 +1,35 @@
+package searches
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/searches"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameTop = "top"
+
+func NewTopCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldName, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameTop,
+		Short: "Retrieve a ranking of your most searched accounts and tags",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			top, err := searches.NewHandler(instagram.NewFs(cmd.Context())).Top(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*top)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldCount, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,40 @@
+package security
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/security"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameAudit = "audit"
+
+func NewAuditCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameAudit,
+		Short: "Flag logins from new devices, new ip ranges or at unusual hours",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			unusualHours, err := cmd.Flags().GetString(instagram.FlagUnusualHours)
+			if err != nil {
+				return err
+			}
+			audit, err := security.NewHandler(instagram.NewFs(cmd.Context())).Audit(opts, unusualHours)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*audit)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().String(instagram.FlagUnusualHours, "0-5", `inclusive range of hours considered unusual for logins, may wrap around midnight (e.g. "22-5")`)
+	return cmd
+}
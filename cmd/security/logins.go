@@ -0,0 +1,35 @@
+package security
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/security"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameLogins = "logins"
+
+func NewLoginsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameLogins,
+		Short: "Retrieve a list of login and logout sessions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			logins, err := security.NewHandler(instagram.NewFs(cmd.Context())).Logins(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*logins)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,39 @@
+package serve
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/server"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameServe = "serve"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CommandNameServe,
+		Example: "curl -H 'Accept: text/csv' 'http://localhost:8080/unfollowers?limit=10&order=asc&sort-by=username'",
+		Short:   "Serve followers, following and unfollowers over a local HTTP API",
+		Long: `Serve followers, following and unfollowers over a local HTTP API.
+
+Endpoints: /health, /followers, /following, /unfollowers.
+Query parameters match the list command flags: limit, offset, page, page-size, order, sort-by (or sort), output ("csv", "json", "yaml").
+The output parameter takes precedence over the Accept header.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cmd.Flags().GetString(instagram.FlagAddr)
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			cmd.PrintErrf("listening on http://%s\n", addr)
+			return server.ListenAndServe(ctx, addr, server.NewHandler(instagram.NewFs(cmd.Context())))
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.Flags().String(instagram.FlagAddr, "localhost:8080", `address to listen on`)
+	return cmd
+}
@@ -0,0 +1,40 @@
+package stories
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/stories"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameCadence = "cadence"
+
+func NewCadenceCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameCadence,
+		Short: "Retrieve the number of stories you posted per period",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			period, err := cmd.Flags().GetString(instagram.FlagPeriod)
+			if err != nil {
+				return err
+			}
+			cadence, err := stories.NewHandler(instagram.NewFs(cmd.Context())).Cadence(opts, period)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*cadence)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().String(instagram.FlagPeriod, instagram.PeriodMonth, `group stories by period ("day", "week", "month")`)
+	return cmd
+}
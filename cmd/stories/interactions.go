@@ -0,0 +1,35 @@
+package stories
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/stories"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameInteractions = "interactions"
+
+func NewInteractionsCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldCount, instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameInteractions,
+		Short: "Retrieve a list of users whose stories you interact with the most",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			interactions, err := stories.NewHandler(instagram.NewFs(cmd.Context())).Interactions(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*interactions)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldCount, sortByFields...)
+	return cmd
+}
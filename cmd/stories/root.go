@@ -0,0 +1,26 @@
+package stories
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const CommandNameStories = "stories"
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [command]", CommandNameStories),
+		Short: "Instagram stories operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(
+		NewCadenceCommand(),
+		NewInteractionsCommand(),
+		NewStickersCommand(),
+	)
+	return cmd
+}
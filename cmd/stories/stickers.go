@@ -0,0 +1,35 @@
+package stories
+
+import (
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/stories"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameStickers = "stickers"
+
+func NewStickersCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp, instagram.FieldUsername}
+	cmd := &cobra.Command{
+		Use:   CommandNameStickers,
+		Short: "Retrieve a list of your story sticker responses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			stickers, err := stories.NewHandler(instagram.NewFs(cmd.Context())).Stickers(opts)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*stickers)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderDesc, instagram.FieldTimestamp, sortByFields...)
+	return cmd
+}
@@ -0,0 +1,53 @@
+package timeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/timeline"
+	"github.com/spf13/cobra"
+)
+
+const CommandNameTimeline = "timeline"
+
+func NewRootCommand() *cobra.Command {
+	sortByFields := []string{instagram.FieldTimestamp}
+	cmd := &cobra.Command{
+		Use:   CommandNameTimeline,
+		Short: "Retrieve a chronological stream of follows, likes, comments, messages, posts, logins and searches",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := instagram.NewOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if err = opts.Validate(sortByFields...); err != nil {
+				return err
+			}
+			since, err := cmd.Flags().GetString(instagram.FlagSince)
+			if err != nil {
+				return err
+			}
+			until, err := cmd.Flags().GetString(instagram.FlagUntil)
+			if err != nil {
+				return err
+			}
+			types, err := cmd.Flags().GetString(instagram.FlagType)
+			if err != nil {
+				return err
+			}
+			events, err := timeline.NewHandler(instagram.NewFs(cmd.Context())).Timeline(opts, since, until, types)
+			if err != nil {
+				return err
+			}
+			cmd.Print(*events)
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+	instagram.AddCommonFlags(cmd, instagram.OrderAsc, instagram.FieldTimestamp, sortByFields...)
+	cmd.Flags().String(instagram.FlagSince, "", `only include events on or after this date (YYYY-MM-DD)`)
+	cmd.Flags().String(instagram.FlagUntil, "", `only include events on or before this date (YYYY-MM-DD)`)
+	cmd.Flags().String(instagram.FlagType, "", fmt.Sprintf(`comma-separated event types to include, omit this flag for all ("%s")`, strings.Join(timeline.Types(), `", "`)))
+	return cmd
+}
@@ -4,10 +4,11 @@ import (
 	"os"
 
 	"github.com/cecobask/instagram-insights/cmd/root"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
 )
 
 func main() {
 	if err := root.NewRootCommand().Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(instagram.ExitCode(err))
 	}
 }
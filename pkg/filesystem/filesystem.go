@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 type Fs interface {
@@ -12,6 +14,7 @@ type Fs interface {
 	CreateDirectory(path string, perm os.FileMode) error
 	CreateFile(name string) (io.WriteCloser, error)
 	FindFiles(pattern string) ([]string, error)
+	Open(name string) (io.ReadCloser, error)
 	OpenFile(name string, flag int, perm os.FileMode) (*os.File, error)
 	OpenZip(name string) (*zip.ReadCloser, error)
 	ReadFile(name string) ([]byte, error)
@@ -21,38 +24,73 @@ type Fs interface {
 	UnzipFile(zipFile *zip.File, destination string) error
 }
 
-type fileSystem struct{}
+type fileSystem struct {
+	base  string
+	roots []string
+}
 
 func NewFs() Fs {
 	return new(fileSystem)
 }
 
+func NewFsWithBase(base string, roots ...string) Fs {
+	return &fileSystem{
+		base:  base,
+		roots: roots,
+	}
+}
+
+func (fs *fileSystem) resolve(name string) string {
+	if fs.base == "" || filepath.IsAbs(name) {
+		return name
+	}
+	root, _, _ := strings.Cut(filepath.ToSlash(filepath.Clean(name)), "/")
+	if !slices.Contains(fs.roots, root) {
+		return name
+	}
+	return filepath.Join(fs.base, name)
+}
+
 func (fs *fileSystem) CopyToFile(destination io.Writer, source io.Reader) (int64, error) {
 	return io.Copy(destination, source)
 }
 
 func (fs *fileSystem) CreateDirectory(path string, perm os.FileMode) error {
-	return os.MkdirAll(path, perm)
+	return os.MkdirAll(fs.resolve(path), perm)
 }
 
 func (fs *fileSystem) CreateFile(name string) (io.WriteCloser, error) {
-	return os.Create(name)
+	return os.Create(fs.resolve(name))
 }
 
 func (fs *fileSystem) FindFiles(pattern string) ([]string, error) {
-	return filepath.Glob(pattern)
+	resolved := fs.resolve(pattern)
+	matches, err := filepath.Glob(resolved)
+	if err != nil || resolved == pattern {
+		return matches, err
+	}
+	for i := range matches {
+		if matches[i], err = filepath.Rel(fs.base, matches[i]); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+func (fs *fileSystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(fs.resolve(name))
 }
 
 func (fs *fileSystem) OpenFile(name string, flag int, perm os.FileMode) (*os.File, error) {
-	return os.OpenFile(name, flag, perm)
+	return os.OpenFile(fs.resolve(name), flag, perm)
 }
 
 func (fs *fileSystem) OpenZip(name string) (*zip.ReadCloser, error) {
-	return zip.OpenReader(name)
+	return zip.OpenReader(fs.resolve(name))
 }
 
 func (fs *fileSystem) ReadFile(name string) ([]byte, error) {
-	return os.ReadFile(name)
+	return os.ReadFile(fs.resolve(name))
 }
 
 func (fs *fileSystem) ReadZipFile(file *zip.File) (io.ReadCloser, error) {
@@ -60,7 +98,7 @@ func (fs *fileSystem) ReadZipFile(file *zip.File) (io.ReadCloser, error) {
 }
 
 func (fs *fileSystem) RemoveDirectory(path string) error {
-	return os.RemoveAll(path)
+	return os.RemoveAll(fs.resolve(path))
 }
 
 func (fs *fileSystem) Unzip(source, destination string) error {
@@ -230,6 +230,60 @@ func (_c *MockFs_FindFiles_Call) RunAndReturn(run func(string) ([]string, error)
 	return _c
 }
 
+// Open provides a mock function with given fields: name
+func (_m *MockFs) Open(name string) (io.ReadCloser, error) {
+	ret := _m.Called(name)
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (io.ReadCloser, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFs_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockFs_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - name string
+func (_e *MockFs_Expecter) Open(name interface{}) *MockFs_Open_Call {
+	return &MockFs_Open_Call{Call: _e.mock.On("Open", name)}
+}
+
+func (_c *MockFs_Open_Call) Run(run func(name string)) *MockFs_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockFs_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *MockFs_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFs_Open_Call) RunAndReturn(run func(string) (io.ReadCloser, error)) *MockFs_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OpenFile provides a mock function with given fields: name, flag, perm
 func (_m *MockFs) OpenFile(name string, flag int, perm fs.FileMode) (*os.File, error) {
 	ret := _m.Called(name, flag, perm)
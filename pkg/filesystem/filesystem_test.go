@@ -0,0 +1,67 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fileSystem_resolve(t *testing.T) {
+	tests := []struct {
+		name string
+		fs   *fileSystem
+		path string
+		want string
+	}{
+		{
+			name: "succeeds to resolve root against base",
+			fs:   &fileSystem{base: "data", roots: []string{"instagram_data", "instagram_data.zip"}},
+			path: "instagram_data/connections/followers.json",
+			want: filepath.Join("data", "instagram_data", "connections", "followers.json"),
+		},
+		{
+			name: "succeeds to resolve archive against base",
+			fs:   &fileSystem{base: "data", roots: []string{"instagram_data", "instagram_data.zip"}},
+			path: "instagram_data.zip",
+			want: filepath.Join("data", "instagram_data.zip"),
+		},
+		{
+			name: "avoids to resolve paths outside of roots",
+			fs:   &fileSystem{base: "data", roots: []string{"instagram_data"}},
+			path: "report.html",
+			want: "report.html",
+		},
+		{
+			name: "avoids to resolve absolute paths",
+			fs:   &fileSystem{base: "data", roots: []string{"instagram_data"}},
+			path: "/tmp/instagram_data",
+			want: "/tmp/instagram_data",
+		},
+		{
+			name: "avoids to resolve without base",
+			fs:   &fileSystem{},
+			path: "instagram_data/posts.json",
+			want: "instagram_data/posts.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fs.resolve(tt.path))
+		})
+	}
+}
+
+func Test_fileSystem_FindFiles(t *testing.T) {
+	base := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(base, "instagram_data", "messages"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(base, "instagram_data", "messages", "message_1.json"), []byte("{}"), 0644))
+	fs := NewFsWithBase(base, "instagram_data")
+	files, err := fs.FindFiles("instagram_data/messages/*.json")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"instagram_data/messages/message_1.json"}, files)
+	data, err := fs.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+}
@@ -0,0 +1,330 @@
+package activity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+)
+
+const (
+	CategoryComment          = "comment"
+	CategoryFollower         = "follower"
+	CategoryFollowing        = "following"
+	CategoryLike             = "like"
+	CategoryLogin            = "login"
+	CategoryMessage          = "message"
+	CategoryPost             = "post"
+	CategorySearch           = "search"
+	CategoryStory            = "story"
+	keyComment               = "Comment"
+	keyMediaOwner            = "Media Owner"
+	keyMessages              = "messages"
+	keyParticipants          = "participants"
+	keySearch                = "Search"
+	keyStoryActivitiesPrefix = "story_activities_"
+	keyTime                  = "Time"
+)
+
+var threadSuffixRegexp = regexp.MustCompile(`_\d+$`)
+
+type Event struct {
+	Category  string
+	Kind      string
+	Actor     string
+	Username  string
+	Thread    string
+	Text      string
+	Sent      bool
+	Timestamp time.Time
+}
+
+func LoadLikes(fileSystem filesystem.Fs) ([]Event, error) {
+	data, err := fileSystem.ReadFile(instagram.PathLikedPosts)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return make([]Event, 0), nil
+		}
+		return nil, err
+	}
+	return parseLikes(data)
+}
+
+func LoadComments(fileSystem filesystem.Fs) ([]Event, error) {
+	return loadFiles(fileSystem, instagram.PathPostComments, parseComments)
+}
+
+func LoadMessages(fileSystem filesystem.Fs) ([]Event, error) {
+	events := make([]Event, 0)
+	err := EachMessage(fileSystem, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func EachMessage(fileSystem filesystem.Fs, visit func(event Event) error) error {
+	files, err := fileSystem.FindFiles(instagram.PathMessages)
+	if err != nil {
+		return err
+	}
+	for i := range files {
+		if err = decodeMessageFile(fileSystem, files[i], visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func LoadAccountSearches(fileSystem filesystem.Fs) ([]Event, error) {
+	data, err := fileSystem.ReadFile(instagram.PathAccountSearches)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return make([]Event, 0), nil
+		}
+		return nil, err
+	}
+	events, err := ParseSearches(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].Username = events[i].Text
+	}
+	return events, nil
+}
+
+func LoadPosts(fileSystem filesystem.Fs) ([]Event, error) {
+	return loadFiles(fileSystem, instagram.PathPosts, parsePosts)
+}
+
+func LoadStoryInteractions(fileSystem filesystem.Fs) ([]Event, error) {
+	return loadFiles(fileSystem, instagram.PathStoryStickers, ParseStoryInteractions)
+}
+
+func loadFiles(fileSystem filesystem.Fs, pattern string, parse func(data []byte) ([]Event, error)) ([]Event, error) {
+	files, err := fileSystem.FindFiles(pattern)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0)
+	for i := range files {
+		data, err := fileSystem.ReadFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parse(data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, parsed...)
+	}
+	return events, nil
+}
+
+func decodeMessageFile(fileSystem filesystem.Fs, name string, visit func(event Event) error) error {
+	file, err := fileSystem.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	thread := threadSuffixRegexp.ReplaceAllString(path.Base(path.Dir(name)), "")
+	return decodeMessages(file, thread, visit)
+}
+
+func parseLikes(data []byte) ([]Event, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			for _, sd := range entry.StringListData {
+				events = append(events, Event{
+					Category:  CategoryLike,
+					Username:  entry.Title,
+					Timestamp: time.Unix(sd.Timestamp, 0),
+				})
+			}
+		}
+	}
+	return events, nil
+}
+
+func parseComments(data []byte) ([]Event, error) {
+	var entries []instagram.Entry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+	} else {
+		jsonData := make(map[string][]instagram.Entry)
+		if err := json.Unmarshal(data, &jsonData); err != nil {
+			return nil, err
+		}
+		for _, e := range jsonData {
+			entries = append(entries, e...)
+		}
+	}
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, Event{
+			Category:  CategoryComment,
+			Username:  entry.StringMapData[keyMediaOwner].Value,
+			Text:      entry.StringMapData[keyComment].Value,
+			Timestamp: time.Unix(entry.StringMapData[keyTime].Timestamp, 0),
+		})
+	}
+	return events, nil
+}
+
+type participantOriginal struct {
+	Name string `json:"name"`
+}
+
+type messageOriginal struct {
+	SenderName  string `json:"sender_name"`
+	TimestampMs int64  `json:"timestamp_ms"`
+	Content     string `json:"content"`
+}
+
+func decodeMessages(r io.Reader, thread string, visit func(event Event) error) error {
+	dec := json.NewDecoder(r)
+	participants := make([]string, 0)
+	return instagram.DecodeObject(dec, func(key string) error {
+		switch key {
+		case keyParticipants:
+			return instagram.DecodeArray(dec, func(participant participantOriginal) error {
+				participants = append(participants, participant.Name)
+				return nil
+			})
+		case keyMessages:
+			owner, username := "", thread
+			if len(participants) > 0 {
+				owner = participants[len(participants)-1]
+			}
+			if len(participants) > 2 {
+				username = ""
+			}
+			return instagram.DecodeArray(dec, func(message messageOriginal) error {
+				return visit(Event{
+					Category:  CategoryMessage,
+					Actor:     message.SenderName,
+					Username:  username,
+					Thread:    thread,
+					Text:      message.Content,
+					Sent:      owner != "" && message.SenderName == owner,
+					Timestamp: time.UnixMilli(message.TimestampMs),
+				})
+			})
+		default:
+			return instagram.SkipValue(dec)
+		}
+	})
+}
+
+type postOriginal struct {
+	Title             string `json:"title"`
+	CreationTimestamp int64  `json:"creation_timestamp"`
+	Media             []struct {
+		Title             string `json:"title"`
+		CreationTimestamp int64  `json:"creation_timestamp"`
+	} `json:"media"`
+}
+
+func parsePosts(data []byte) ([]Event, error) {
+	var posts []postOriginal
+	if err := json.Unmarshal(data, &posts); err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(posts))
+	for _, post := range posts {
+		title, unix := post.Title, post.CreationTimestamp
+		if len(post.Media) > 0 {
+			if title == "" {
+				title = post.Media[0].Title
+			}
+			if unix == 0 {
+				unix = post.Media[0].CreationTimestamp
+			}
+		}
+		events = append(events, Event{
+			Category:  CategoryPost,
+			Text:      title,
+			Timestamp: time.Unix(unix, 0),
+		})
+	}
+	return events, nil
+}
+
+func ParseSearches(data []byte) ([]Event, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			query := entry.StringMapData[keySearch].Value
+			unix := entry.StringMapData[keyTime].Timestamp
+			if len(entry.StringListData) > 0 {
+				if query == "" {
+					query = entry.StringListData[0].Value
+				}
+				if unix == 0 {
+					unix = entry.StringListData[0].Timestamp
+				}
+			}
+			if query == "" {
+				query = entry.Title
+			}
+			events = append(events, Event{
+				Category:  CategorySearch,
+				Text:      query,
+				Timestamp: time.Unix(unix, 0),
+			})
+		}
+	}
+	return events, nil
+}
+
+func ParseStoryInteractions(data []byte) ([]Event, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(jsonData))
+	for key := range jsonData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	events := make([]Event, 0)
+	for _, key := range keys {
+		kind := strings.TrimPrefix(key, keyStoryActivitiesPrefix)
+		for _, entry := range jsonData[key] {
+			for _, sd := range entry.StringListData {
+				events = append(events, Event{
+					Category:  CategoryStory,
+					Kind:      kind,
+					Username:  entry.Title,
+					Text:      sd.Value,
+					Timestamp: time.Unix(sd.Timestamp, 0),
+				})
+			}
+		}
+	}
+	return events, nil
+}
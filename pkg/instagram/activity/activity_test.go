@@ -0,0 +1,450 @@
+package activity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	likedPostsJson     = `{"likes_media_likes":[{"title":"username1","string_list_data":[{"href":"https://www.instagram.com/p/1","value":"👍","timestamp":1696161600}]}]}`
+	postCommentsJson   = `[{"string_map_data":{"Comment":{"value":"nice"},"Media Owner":{"value":"username2"},"Time":{"timestamp":1696165200}}}]`
+	reelsCommentsJson  = `{"comments_reels_comments":[{"string_map_data":{"Comment":{"value":"cool"},"Media Owner":{"value":"username3"},"Time":{"timestamp":1696168800}}}]}`
+	directThreadJson   = `{"participants":[{"name":"User One"},{"name":"Me"}],"messages":[{"sender_name":"Me","timestamp_ms":1696165200000,"content":"hello"},{"sender_name":"User One","timestamp_ms":1696161600000,"content":"hi"}],"thread_path":"inbox/username1_1234567890"}`
+	groupThreadJson    = `{"participants":[{"name":"User One"},{"name":"User Two"},{"name":"Me"}],"messages":[{"sender_name":"User Two","timestamp_ms":1696161600000,"content":"hey"}],"thread_path":"inbox/group_1234567890"}`
+	directThreadFile   = "instagram_data/your_instagram_activity/messages/inbox/username1_1234567890/message_1.json"
+	groupThreadFile    = "instagram_data/your_instagram_activity/messages/inbox/group_1234567890/message_1.json"
+	accountSearchJson  = `{"searches_user":[{"string_map_data":{"Search":{"value":"username5"},"Time":{"timestamp":1696176000}}},{"title":"username6","string_list_data":[{"value":"username6","timestamp":1696179600}]}]}`
+	postsJson          = `[{"media":[{"uri":"media/posts/1.jpg","creation_timestamp":1696183200,"title":"caption"}]},{"title":"album","creation_timestamp":1696186800,"media":[{"uri":"media/posts/2.jpg","creation_timestamp":0}]}]`
+	storyStickersJson  = `{"story_activities_polls":[{"title":"username4","string_list_data":[{"value":"yes","timestamp":1696172400}]}]}`
+	emptyStickersJson  = `{"story_activities_polls":[]}`
+	invalidFileContent = ``
+)
+
+func TestLoadLikes(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load likes",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likedPostsJson), nil)
+			},
+			want: []Event{
+				{Category: CategoryLike, Username: "username1", Timestamp: time.Unix(1696161600, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to load likes when file is missing",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathLikedPosts).Return(nil, fs.ErrNotExist)
+			},
+			want:    []Event{},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathLikedPosts).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to parse likes",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathLikedPosts).Return([]byte(invalidFileContent), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadLikes(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadLikes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadComments(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load comments in list and map formats",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPostComments).Return([]string{"file1", "file2"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(postCommentsJson), nil)
+				f.On("ReadFile", "file2").Return([]byte(reelsCommentsJson), nil)
+			},
+			want: []Event{
+				{Category: CategoryComment, Username: "username2", Text: "nice", Timestamp: time.Unix(1696165200, 0)},
+				{Category: CategoryComment, Username: "username3", Text: "cool", Timestamp: time.Unix(1696168800, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to find files",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPostComments).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPostComments).Return([]string{"file1"}, nil)
+				f.On("ReadFile", "file1").Return(nil, fmt.Errorf("fails to read file"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to parse comments",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPostComments).Return([]string{"file1"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(invalidFileContent), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadComments(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadComments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadMessages(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load direct and group messages",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return([]string{directThreadFile, groupThreadFile}, nil)
+				f.On("Open", directThreadFile).Return(io.NopCloser(strings.NewReader(directThreadJson)), nil)
+				f.On("Open", groupThreadFile).Return(io.NopCloser(strings.NewReader(groupThreadJson)), nil)
+			},
+			want: []Event{
+				{Category: CategoryMessage, Actor: "Me", Username: "username1", Thread: "username1", Text: "hello", Sent: true, Timestamp: time.UnixMilli(1696165200000)},
+				{Category: CategoryMessage, Actor: "User One", Username: "username1", Thread: "username1", Text: "hi", Timestamp: time.UnixMilli(1696161600000)},
+				{Category: CategoryMessage, Actor: "User Two", Username: "", Thread: "group", Text: "hey", Timestamp: time.UnixMilli(1696161600000)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse messages",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(invalidFileContent)), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadMessages(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadMessages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEachMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		visit        func(event Event) error
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to visit messages",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return([]string{groupThreadFile}, nil)
+				f.On("Open", groupThreadFile).Return(io.NopCloser(strings.NewReader(groupThreadJson)), nil)
+			},
+			want: []Event{
+				{Category: CategoryMessage, Actor: "User Two", Thread: "group", Text: "hey", Timestamp: time.UnixMilli(1696161600000)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to find files",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to open file",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return([]string{groupThreadFile}, nil)
+				f.On("Open", groupThreadFile).Return(nil, fmt.Errorf("fails to open file"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to visit message",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathMessages).Return([]string{directThreadFile}, nil)
+				f.On("Open", directThreadFile).Return(io.NopCloser(strings.NewReader(directThreadJson)), nil)
+			},
+			visit: func(Event) error {
+				return fmt.Errorf("fails to visit message")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got := make([]Event, 0)
+			visit := tt.visit
+			if visit == nil {
+				visit = func(event Event) error {
+					got = append(got, event)
+					return nil
+				}
+			}
+			err := EachMessage(f, visit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EachMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadAccountSearches(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load account searches",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathAccountSearches).Return([]byte(accountSearchJson), nil)
+			},
+			want: []Event{
+				{Category: CategorySearch, Username: "username5", Text: "username5", Timestamp: time.Unix(1696176000, 0)},
+				{Category: CategorySearch, Username: "username6", Text: "username6", Timestamp: time.Unix(1696179600, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to load account searches when file is missing",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathAccountSearches).Return(nil, fs.ErrNotExist)
+			},
+			want:    []Event{},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse account searches",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("ReadFile", instagram.PathAccountSearches).Return([]byte(invalidFileContent), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadAccountSearches(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadAccountSearches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadPosts(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load posts",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPosts).Return([]string{"file1"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(postsJson), nil)
+			},
+			want: []Event{
+				{Category: CategoryPost, Text: "caption", Timestamp: time.Unix(1696183200, 0)},
+				{Category: CategoryPost, Text: "album", Timestamp: time.Unix(1696186800, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse posts",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathPosts).Return([]string{"file1"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(invalidFileContent), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadPosts(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadPosts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadStoryInteractions(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []Event
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load story interactions",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathStoryStickers).Return([]string{"file1", "file2"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(storyStickersJson), nil)
+				f.On("ReadFile", "file2").Return([]byte(emptyStickersJson), nil)
+			},
+			want: []Event{
+				{Category: CategoryStory, Kind: "polls", Username: "username4", Text: "yes", Timestamp: time.Unix(1696172400, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse story interactions",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathStoryStickers).Return([]string{"file1"}, nil)
+				f.On("ReadFile", "file1").Return([]byte(invalidFileContent), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			got, err := LoadStoryInteractions(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadStoryInteractions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func generateMessages(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"participants":[{"name":"User One"},{"name":"Me"}],"messages":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"sender_name":"User One","timestamp_ms":%d,"content":"message %d"}`, 1696161600000+int64(i), i)
+	}
+	buf.WriteString(`],"thread_path":"inbox/username1_1234567890"}`)
+	return buf.Bytes()
+}
+
+func benchmarkMessages(b *testing.B, load func(f filesystem.Fs) (int, error)) {
+	const entries = 500000
+	content := generateMessages(entries)
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathMessages).Return([]string{directThreadFile}, nil)
+	f.On("Open", directThreadFile).Return(func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count, err := load(f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != entries {
+			b.Fatalf("got %d events, want %d", count, entries)
+		}
+	}
+}
+
+func BenchmarkLoadMessages(b *testing.B) {
+	benchmarkMessages(b, func(f filesystem.Fs) (int, error) {
+		events, err := LoadMessages(f)
+		return len(events), err
+	})
+}
+
+func BenchmarkEachMessage(b *testing.B) {
+	benchmarkMessages(b, func(f filesystem.Fs) (int, error) {
+		count := 0
+		err := EachMessage(f, func(Event) error {
+			count++
+			return nil
+		})
+		return count, err
+	})
+}
@@ -0,0 +1,197 @@
+package ads
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const keyInterest = "Interest"
+
+type Interface interface {
+	Advertisers(opts *instagram.Options, customAudience bool, remarketing bool) (*string, error)
+	Interests(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Advertisers(opts *instagram.Options, customAudience bool, remarketing bool) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathAdsAdvertisers)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathAdsAdvertisers, err)
+	}
+	al, err := parseAdvertisers(data)
+	if err != nil {
+		return nil, err
+	}
+	al.Filter(customAudience, remarketing)
+	al.Sort(opts.SortBy, opts.Order)
+	al.Paginate(opts)
+	return instagram.Output(al, opts)
+}
+
+func (h *handler) Interests(opts *instagram.Options) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathAdsInterests)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathAdsInterests, err)
+	}
+	il, err := parseInterests(data)
+	if err != nil {
+		return nil, err
+	}
+	il.Sort(opts.SortBy, opts.Order)
+	il.Paginate(opts)
+	return instagram.Output(il, opts)
+}
+
+func parseAdvertisers(data []byte) (*advertiserList, error) {
+	jsonData := make(map[string][]advertiserOriginal)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	al := &advertiserList{
+		advertisers: make([]advertiser, 0),
+	}
+	for _, advertisers := range jsonData {
+		for _, a := range advertisers {
+			al.advertisers = append(al.advertisers, advertiser(a))
+		}
+	}
+	return al, nil
+}
+
+func parseInterests(data []byte) (*interestList, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	il := &interestList{
+		interests: make([]interest, 0),
+	}
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			il.interests = append(il.interests, interest{
+				Name: entry.StringMapData[keyInterest].Value,
+			})
+		}
+	}
+	return il, nil
+}
+
+type advertiserOriginal struct {
+	Name           string `json:"advertiser_name"`
+	CustomAudience bool   `json:"has_data_file_custom_audience"`
+	Remarketing    bool   `json:"has_remarketing_custom_audience"`
+	InPersonVisit  bool   `json:"has_in_person_store_visit"`
+}
+
+type advertiser struct {
+	Name           string `json:"name" yaml:"name"`
+	CustomAudience bool   `json:"customAudience" yaml:"customAudience"`
+	Remarketing    bool   `json:"remarketing" yaml:"remarketing"`
+	InPersonVisit  bool   `json:"inPersonVisit" yaml:"inPersonVisit"`
+}
+
+type advertiserList struct {
+	advertisers []advertiser
+}
+
+func (al *advertiserList) Data() any {
+	return al.advertisers
+}
+
+func (al *advertiserList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderAdvertiser,
+		instagram.TableHeaderCustomAudience,
+		instagram.TableHeaderRemarketing,
+		instagram.TableHeaderInPersonVisit,
+	}
+}
+
+func (al *advertiserList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range al.advertisers {
+		current := al.advertisers[i]
+		rows = append(rows, table.Row{
+			current.Name,
+			current.CustomAudience,
+			current.Remarketing,
+			current.InPersonVisit,
+		})
+	}
+	return rows
+}
+
+func (al *advertiserList) Filter(customAudience bool, remarketing bool) {
+	al.advertisers = slices.DeleteFunc(al.advertisers, func(a advertiser) bool {
+		return (customAudience && !a.CustomAudience) || (remarketing && !a.Remarketing)
+	})
+}
+
+func (al *advertiserList) Sort(_ string, order string) {
+	sort.SliceStable(al.advertisers, func(a, b int) bool {
+		return strings.ToLower(al.advertisers[a].Name) < strings.ToLower(al.advertisers[b].Name)
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(al.advertisers)
+	}
+}
+
+func (al *advertiserList) Paginate(opts *instagram.Options) {
+	al.advertisers = instagram.Paginate(al.advertisers, opts)
+}
+
+type interest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type interestList struct {
+	interests []interest
+}
+
+func (il *interestList) Data() any {
+	return il.interests
+}
+
+func (il *interestList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderInterest,
+	}
+}
+
+func (il *interestList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range il.interests {
+		rows = append(rows, table.Row{
+			il.interests[i].Name,
+		})
+	}
+	return rows
+}
+
+func (il *interestList) Sort(_ string, order string) {
+	sort.SliceStable(il.interests, func(a, b int) bool {
+		return strings.ToLower(il.interests[a].Name) < strings.ToLower(il.interests[b].Name)
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(il.interests)
+	}
+}
+
+func (il *interestList) Paginate(opts *instagram.Options) {
+	il.interests = instagram.Paginate(il.interests, opts)
+}
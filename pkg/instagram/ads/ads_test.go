@@ -0,0 +1,191 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	advertisersJson = `{"ig_custom_audiences_all_types":[{"advertiser_name":"Brand B","has_data_file_custom_audience":true,"has_remarketing_custom_audience":false,"has_in_person_store_visit":false},{"advertiser_name":"brand a","has_data_file_custom_audience":true,"has_remarketing_custom_audience":true,"has_in_person_store_visit":true},{"advertiser_name":"Brand C","has_data_file_custom_audience":false,"has_remarketing_custom_audience":true,"has_in_person_store_visit":false}]}`
+	interestsJson   = `{"inferred_data_ig_interest":[{"string_map_data":{"Interest":{"value":"Cooking"}}},{"string_map_data":{"Interest":{"value":"Bicycles"}}}]}`
+)
+
+func Test_handler_Advertisers(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output advertisers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsAdvertisers).Return([]byte(advertisersJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsAdvertisers).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse advertisers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsAdvertisers).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Advertisers(instagram.NewEmptyOptions(), false, false); (err != nil) != tt.wantErr {
+				t.Errorf("Advertisers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Interests(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output interests",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsInterests).Return([]byte(interestsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsInterests).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse interests",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAdsInterests).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Interests(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Interests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_advertiserList_Filter(t *testing.T) {
+	tests := []struct {
+		name           string
+		customAudience bool
+		remarketing    bool
+		want           []string
+	}{
+		{
+			name: "keeps all advertisers without filters",
+			want: []string{"brand a", "Brand B", "Brand C"},
+		},
+		{
+			name:           "keeps custom audience advertisers",
+			customAudience: true,
+			want:           []string{"brand a", "Brand B"},
+		},
+		{
+			name:        "keeps remarketing advertisers",
+			remarketing: true,
+			want:        []string{"brand a", "Brand C"},
+		},
+		{
+			name:           "keeps advertisers matching both filters",
+			customAudience: true,
+			remarketing:    true,
+			want:           []string{"brand a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			al, err := parseAdvertisers([]byte(advertisersJson))
+			assert.NoError(t, err)
+			al.Filter(tt.customAudience, tt.remarketing)
+			al.Sort(instagram.FieldName, instagram.OrderAsc)
+			var got []string
+			for _, a := range al.advertisers {
+				got = append(got, a.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_interestList_Sort(t *testing.T) {
+	il, err := parseInterests([]byte(interestsJson))
+	assert.NoError(t, err)
+	il.Sort(instagram.FieldName, instagram.OrderDesc)
+	assert.Equal(t, "Cooking", il.interests[0].Name)
+	il.Paginate(&instagram.Options{Limit: 1})
+	assert.Equal(t, 1, len(il.interests))
+}
@@ -0,0 +1,191 @@
+package instagram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+type columnOutputter struct {
+	source  Outputter
+	keys    []string
+	indexes []int
+}
+
+type columnData struct {
+	data any
+	keys []string
+}
+
+func selectColumns(o Outputter, format string, columns []string) (Outputter, error) {
+	co := &columnOutputter{
+		source: o,
+		keys:   make([]string, len(columns)),
+	}
+	for i := range columns {
+		co.keys[i] = columnKey(columns[i])
+	}
+	header := o.TableHeader()
+	switch format {
+	case OutputCsv, OutputMarkdown, OutputTable:
+		for i, key := range co.keys {
+			index := slices.IndexFunc(header, func(cell any) bool {
+				return columnKey(fmt.Sprint(cell)) == key
+			})
+			if index < 0 {
+				return nil, fmt.Errorf("invalid column: %s", columns[i])
+			}
+			co.indexes = append(co.indexes, index)
+		}
+	case OutputJson, OutputJsonl, OutputYaml:
+		items, err := jsonItems(o.Data())
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[string]bool)
+		for _, cell := range header {
+			known[columnKey(fmt.Sprint(cell))] = true
+		}
+		for _, item := range items {
+			for name := range item {
+				known[columnKey(name)] = true
+			}
+		}
+		for i, key := range co.keys {
+			if !known[key] {
+				return nil, fmt.Errorf("invalid column: %s", columns[i])
+			}
+		}
+	}
+	return co, nil
+}
+
+func (co *columnOutputter) Data() any {
+	return columnData{
+		data: co.source.Data(),
+		keys: co.keys,
+	}
+}
+
+func (co *columnOutputter) TableHeader() table.Row {
+	return selectCells(co.source.TableHeader(), co.indexes)
+}
+
+func (co *columnOutputter) TableRows() []table.Row {
+	rows := co.source.TableRows()
+	selected := make([]table.Row, 0, len(rows))
+	for i := range rows {
+		selected = append(selected, selectCells(rows[i], co.indexes))
+	}
+	return selected
+}
+
+func (cd columnData) MarshalJSON() ([]byte, error) {
+	items, err := jsonItems(cd.data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		names := make(map[string]string, len(item))
+		for name := range item {
+			names[columnKey(name)] = name
+		}
+		buf.WriteByte('{')
+		written := 0
+		for _, key := range cd.keys {
+			name, ok := names[key]
+			if !ok {
+				continue
+			}
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+			encodedName, err := json.Marshal(name)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(encodedName)
+			buf.WriteByte(':')
+			buf.Write(item[name])
+			written++
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+func (cd columnData) MarshalYAML() (any, error) {
+	data, err := yaml.Marshal(cd.data)
+	if err != nil {
+		return nil, err
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("columns are not supported for this output")
+	}
+	sequence := document.Content[0]
+	for _, item := range sequence.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("columns are not supported for this output")
+		}
+		content := make([]*yaml.Node, 0, len(cd.keys)*2)
+		for _, key := range cd.keys {
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				if columnKey(item.Content[i].Value) == key {
+					content = append(content, item.Content[i], item.Content[i+1])
+					break
+				}
+			}
+		}
+		item.Content = content
+	}
+	return sequence, nil
+}
+
+func jsonItems(data any) ([]map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]json.RawMessage
+	if err = json.Unmarshal(encoded, &items); err != nil {
+		return nil, fmt.Errorf("columns are not supported for this output")
+	}
+	return items, nil
+}
+
+func selectCells(row table.Row, indexes []int) table.Row {
+	selected := make(table.Row, 0, len(indexes))
+	for _, index := range indexes {
+		if index < len(row) {
+			selected = append(selected, row[index])
+		} else {
+			selected = append(selected, "")
+		}
+	}
+	return selected
+}
+
+func columnKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
@@ -0,0 +1,153 @@
+package instagram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	DataDir  string              `yaml:"dataDir"`
+	Source   string              `yaml:"source"`
+	Defaults Defaults            `yaml:"defaults"`
+	Commands map[string]Defaults `yaml:"commands"`
+}
+
+type Defaults struct {
+	Limit  *int   `yaml:"limit"`
+	Order  string `yaml:"order"`
+	Output string `yaml:"output"`
+	SortBy string `yaml:"sortBy"`
+}
+
+type configKey struct{}
+
+func NewConfig(flags *pflag.FlagSet) (*Config, error) {
+	path, err := flags.GetString(FlagConfig)
+	if err != nil {
+		return nil, err
+	}
+	dataDir, err := flags.GetString(FlagDataDir)
+	if err != nil {
+		return nil, err
+	}
+	required := true
+	if !flags.Changed(FlagConfig) {
+		if path = os.Getenv(EnvConfig); path == "" {
+			if path, err = DefaultConfigPath(); err != nil {
+				return nil, err
+			}
+			required = false
+		}
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		if !required && errors.Is(err, fs.ErrNotExist) {
+			config = &Config{}
+		} else {
+			return nil, err
+		}
+	}
+	if flags.Changed(FlagDataDir) {
+		config.DataDir = dataDir
+	} else if value, ok := os.LookupEnv(EnvDataDir); ok {
+		config.DataDir = value
+	}
+	if value, ok := os.LookupEnv(EnvSource); ok {
+		config.Source = value
+	}
+	return config, nil
+}
+
+func DefaultConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, ConfigDirName, ConfigFileName), nil
+}
+
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func WithConfig(ctx context.Context, config *Config) context.Context {
+	return context.WithValue(ctx, configKey{}, config)
+}
+
+func ConfigFromContext(ctx context.Context) *Config {
+	if ctx != nil {
+		if config, ok := ctx.Value(configKey{}).(*Config); ok && config != nil {
+			return config
+		}
+	}
+	return &Config{}
+}
+
+func NewFs(ctx context.Context) filesystem.Fs {
+	return filesystem.NewFsWithBase(ConfigFromContext(ctx).DataDir, PathData, PathDataArchive)
+}
+
+func (c *Config) lookup(command string, key string) (string, bool) {
+	for _, defaults := range []Defaults{c.Commands[command], c.Defaults} {
+		if value, ok := defaults.get(key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (d Defaults) get(key string) (string, bool) {
+	switch key {
+	case FlagLimit:
+		if d.Limit != nil {
+			return strconv.Itoa(*d.Limit), true
+		}
+	case FlagOrder:
+		return d.Order, d.Order != ""
+	case FlagOutput:
+		return d.Output, d.Output != ""
+	case FlagSortBy:
+		return d.SortBy, d.SortBy != ""
+	}
+	return "", false
+}
+
+func lookupEnv(command string, key string) (string, bool) {
+	replacer := strings.NewReplacer(" ", "_", "-", "_")
+	names := []string{
+		EnvPrefix + strings.ToUpper(replacer.Replace(command+" "+key)),
+		EnvPrefix + strings.ToUpper(replacer.Replace(key)),
+	}
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
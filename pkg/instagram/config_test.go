@@ -0,0 +1,235 @@
+package instagram
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	configYaml = `dataDir: /data
+source: https://drive.google.com/file/d/xyz
+defaults:
+  limit: 10
+  output: json
+commands:
+  followdata followers:
+    sortBy: username
+`
+	invalidConfigYaml = `unknown: value`
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewConfig(t *testing.T) {
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("", pflag.ContinueOnError)
+		flags.String(FlagConfig, "", "")
+		flags.String(FlagDataDir, "", "")
+		_ = flags.Parse(args)
+		return flags
+	}
+	limit := 10
+	tests := []struct {
+		name    string
+		flags   func(t *testing.T) *pflag.FlagSet
+		env     func(t *testing.T) map[string]string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "succeeds to load config from flag",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return newFlags("--config", writeConfig(t, configYaml))
+			},
+			want: &Config{
+				DataDir:  "/data",
+				Source:   "https://drive.google.com/file/d/xyz",
+				Defaults: Defaults{Limit: &limit, Output: OutputJson},
+				Commands: map[string]Defaults{"followdata followers": {SortBy: FieldUsername}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to load config from env and override data dir and source",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return newFlags()
+			},
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					EnvConfig:  writeConfig(t, configYaml),
+					EnvDataDir: "/env",
+					EnvSource:  "file:///instagram_data.zip",
+				}
+			},
+			want: &Config{
+				DataDir:  "/env",
+				Source:   "file:///instagram_data.zip",
+				Defaults: Defaults{Limit: &limit, Output: OutputJson},
+				Commands: map[string]Defaults{"followdata followers": {SortBy: FieldUsername}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to resolve data dir flag before env",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return newFlags("--data-dir", "/flag")
+			},
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					"XDG_CONFIG_HOME": t.TempDir(),
+					EnvDataDir:        "/env",
+				}
+			},
+			want: &Config{
+				DataDir: "/flag",
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to ignore missing default config",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return newFlags()
+			},
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					"XDG_CONFIG_HOME": t.TempDir(),
+				}
+			},
+			want:    &Config{},
+			wantErr: false,
+		},
+		{
+			name: "fails to load missing config from flag",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return newFlags("--config", filepath.Join(t.TempDir(), ConfigFileName))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to find flag config",
+			flags: func(t *testing.T) *pflag.FlagSet {
+				return pflag.NewFlagSet("", pflag.ContinueOnError)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				for key, value := range tt.env(t) {
+					t.Setenv(key, value)
+				}
+			}
+			got, err := NewConfig(tt.flags(t))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name:    "succeeds to load empty config",
+			content: "",
+			want:    &Config{},
+			wantErr: false,
+		},
+		{
+			name:    "fails to load config with unknown fields",
+			content: invalidConfigYaml,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadConfig(writeConfig(t, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "succeeds to use xdg config home",
+			env: map[string]string{
+				"XDG_CONFIG_HOME": "/xdg",
+			},
+			want: "/xdg/instagram-insights/config.yaml",
+		},
+		{
+			name: "succeeds to fall back to home directory",
+			env: map[string]string{
+				"HOME":            "/home/username",
+				"XDG_CONFIG_HOME": "",
+			},
+			want: "/home/username/.config/instagram-insights/config.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			got, err := DefaultConfigPath()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfigFromContext(t *testing.T) {
+	config := &Config{DataDir: "/data"}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want *Config
+	}{
+		{
+			name: "succeeds to retrieve config",
+			ctx:  WithConfig(context.Background(), config),
+			want: config,
+		},
+		{
+			name: "succeeds to fall back to empty config",
+			ctx:  context.Background(),
+			want: &Config{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConfigFromContext(tt.ctx))
+		})
+	}
+}
@@ -1,30 +1,154 @@
 package instagram
 
 const (
+	FieldCount     = "count"
+	FieldName      = "name"
+	FieldScore     = "score"
 	FieldTimestamp = "timestamp"
 	FieldUsername  = "username"
 	Unlimited      = 0
 	OrderAsc       = "asc"
 	OrderDesc      = "desc"
+	OutputCsv      = "csv"
 	OutputJson     = "json"
+	OutputJsonl    = "jsonl"
+	OutputMarkdown = "markdown"
 	OutputNone     = "none"
 	OutputTable    = "table"
+	OutputTemplate = "template"
 	OutputYaml     = "yaml"
+	PeriodDay      = "day"
+	PeriodMonth    = "month"
+	PeriodWeek     = "week"
+	RedactedValue  = "[redacted]"
 )
 
 const (
+	ConfigDirName              = "instagram-insights"
+	ConfigFileName             = "config.yaml"
+	EnvConfig                  = EnvPrefix + "CONFIG"
+	EnvDataDir                 = EnvPrefix + "DATA_DIR"
+	EnvPrefix                  = "INSTAGRAM_"
+	EnvSource                  = EnvPrefix + "SOURCE"
+	FlagAddr                   = "addr"
+	FlagCategory               = "category"
+	FlagColumns                = "columns"
+	FlagConfig                 = "config"
+	FlagCustomAudience         = "custom-audience"
+	FlagDataDir                = "data-dir"
+	FlagHtml                   = "html"
+	FlagIgnoreCase             = "ignore-case"
 	FlagLimit                  = "limit"
+	FlagMatched                = "matched"
+	FlagNotFollowing           = "not-following"
+	FlagOffset                 = "offset"
 	FlagOrder                  = "order"
 	FlagOutput                 = "output"
+	FlagPage                   = "page"
+	FlagPageSize               = "page-size"
+	FlagPeriod                 = "period"
+	FlagRegex                  = "regex"
+	FlagRemarketing            = "remarketing"
+	FlagReveal                 = "reveal"
+	FlagSince                  = "since"
 	FlagSortBy                 = "sort-by"
+	FlagSqlite                 = "sqlite"
+	FlagTemplate               = "template"
+	FlagTemplateFile           = "template-file"
+	FlagTimezone               = "timezone"
+	FlagType                   = "type"
+	FlagUntil                  = "until"
+	FlagUnusualHours           = "unusual-hours"
+	FlagWeights                = "weights"
 	GoogleDriveHost            = "drive.google.com"
 	GoogleDriveParsedUrlFormat = "https://drive.google.com/u/0/uc?id=%s&export=download&confirm=t"
+	PathAccountInformation     = PathData + "/personal_information/account_information.json"
+	PathAccountLocation        = PathData + "/information_about_you/account_based_in.json"
+	PathAccountSearches        = PathData + "/logged_information/recent_searches/account_searches.json"
+	PathAdsAdvertisers         = PathData + "/ads_information/instagram_ads_and_businesses/advertisers_using_your_activity_or_information.json"
+	PathAdsInterests           = PathData + "/ads_information/instagram_ads_and_businesses/ads_interests.json"
+	PathAdsViewed              = PathData + "/ads_information/ads_and_topics/ads_viewed.json"
 	PathData                   = "instagram_data"
 	PathDataArchive            = PathData + ".zip"
 	PathDocs                   = "docs"
 	PathFollowers              = PathData + "/connections/followers_and_following/followers_*.json"
 	PathFollowing              = PathData + "/connections/followers_and_following/following.json"
+	PathKeywordSearches        = PathData + "/logged_information/recent_searches/word_or_phrase_searches.json"
+	PathLikedPosts             = PathData + "/your_instagram_activity/likes/liked_posts.json"
+	PathLocationsOfInterest    = PathData + "/information_about_you/locations_of_interest.json"
+	PathLoginActivity          = PathData + "/security_and_login_information/login_and_account_creation/login_activity.json"
+	PathLogoutActivity         = PathData + "/security_and_login_information/login_and_account_creation/logout_activity.json"
+	PathMessages               = PathData + "/your_instagram_activity/messages/inbox/*/message_*.json"
+	PathOffMetaActivity        = PathData + "/apps_and_websites_off_of_instagram/apps_and_websites/your_activity_off_meta_technologies.json"
+	PathPasswordChangeActivity = PathData + "/security_and_login_information/login_and_account_creation/password_change_activity.json"
+	PathPersonalInformation    = PathData + "/personal_information/personal_information.json"
+	PathPostComments           = PathData + "/your_instagram_activity/comments/post_comments_*.json"
+	PathPosts                  = PathData + "/content/posts_*.json"
+	PathPostsViewed            = PathData + "/ads_information/ads_and_topics/posts_viewed.json"
+	PathProfileChanges         = PathData + "/personal_information/profile_changes.json"
+	PathSavedCollections       = PathData + "/your_instagram_activity/saved/saved_collections.json"
+	PathSavedPosts             = PathData + "/your_instagram_activity/saved/saved_posts.json"
+	PathSignupInformation      = PathData + "/security_and_login_information/login_and_account_creation/signup_information.json"
+	PathStories                = PathData + "/content/stories.json"
+	PathStoryStickers          = PathData + "/story_sticker_interactions/*.json"
+	PathSuggestedProfiles      = PathData + "/ads_information/ads_and_topics/suggested_profiles_viewed.json"
+	PathSyncedContacts         = PathData + "/connections/contacts/synced_contacts.json"
+	PathTagSearches            = PathData + "/logged_information/recent_searches/tag_searches.json"
+	PathVideosWatched          = PathData + "/ads_information/ads_and_topics/videos_watched.json"
+	ProfileUrlFormat           = "https://www.instagram.com/%s"
+	TableHeaderActor           = "ACTOR"
+	TableHeaderAds             = "ADS"
+	TableHeaderAdvertiser      = "ADVERTISER"
+	TableHeaderCategory        = "CATEGORY"
+	TableHeaderCollection      = "COLLECTION"
+	TableHeaderComments        = "COMMENTS"
+	TableHeaderContact         = "CONTACT"
+	TableHeaderCustomAudience  = "CUSTOM AUDIENCE"
+	TableHeaderDay             = "DAY"
+	TableHeaderEvent           = "EVENT"
+	TableHeaderEventTypes      = "EVENT TYPES"
+	TableHeaderEvents          = "EVENTS"
+	TableHeaderField           = "FIELD"
+	TableHeaderFile            = "FILE"
+	TableHeaderFirstEvent      = "FIRST EVENT"
+	TableHeaderFollower        = "FOLLOWER"
+	TableHeaderFollowers       = "FOLLOWERS"
+	TableHeaderFollowing       = "FOLLOWING"
+	TableHeaderInPersonVisit   = "IN-PERSON VISIT"
+	TableHeaderInteractions    = "INTERACTIONS"
+	TableHeaderInterest        = "INTEREST"
+	TableHeaderIpAddress       = "IP ADDRESS"
+	TableHeaderLastEvent       = "LAST EVENT"
+	TableHeaderLastInteraction = "LAST INTERACTION"
+	TableHeaderLastSearched    = "LAST SEARCHED"
+	TableHeaderLastSeen        = "LAST SEEN"
+	TableHeaderLikes           = "LIKES"
+	TableHeaderLocation        = "LOCATION"
+	TableHeaderMessages        = "MESSAGES"
+	TableHeaderName            = "NAME"
+	TableHeaderNewValue        = "NEW VALUE"
+	TableHeaderPeriod          = "PERIOD"
+	TableHeaderPosts           = "POSTS"
+	TableHeaderPreviousValue   = "PREVIOUS VALUE"
 	TableHeaderProfileUrl      = "PROFILE URL"
+	TableHeaderQuery           = "QUERY"
+	TableHeaderReasons         = "REASONS"
+	TableHeaderRemarketing     = "REMARKETING"
+	TableHeaderResponse        = "RESPONSE"
+	TableHeaderScore           = "SCORE"
+	TableHeaderSearches        = "SEARCHES"
+	TableHeaderSnippet         = "SNIPPET"
+	TableHeaderSticker         = "STICKER"
+	TableHeaderStories         = "STORIES"
+	TableHeaderSuggested       = "SUGGESTED"
+	TableHeaderTarget          = "TARGET"
+	TableHeaderTier            = "TIER"
 	TableHeaderTimestamp       = "TIMESTAMP"
+	TableHeaderTotal           = "TOTAL"
+	TableHeaderType            = "TYPE"
+	TableHeaderUrl             = "URL"
+	TableHeaderUserAgent       = "USER AGENT"
 	TableHeaderUsername        = "USERNAME"
+	TableHeaderValue           = "VALUE"
+	TableHeaderVideos          = "VIDEOS"
 )
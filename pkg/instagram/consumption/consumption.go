@@ -0,0 +1,337 @@
+package consumption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	keyAuthor     = "Author"
+	keyTime       = "Time"
+	keyUsername   = "Username"
+	kindAd        = "ad"
+	kindPost      = "post"
+	kindSuggested = "suggested"
+	kindVideo     = "video"
+)
+
+type Interface interface {
+	Creators(opts *instagram.Options, notFollowing bool) (*string, error)
+	Daily(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Creators(opts *instagram.Options, notFollowing bool) (*string, error) {
+	impressions, err := h.loadImpressions()
+	if err != nil {
+		return nil, err
+	}
+	following, err := followdata.LoadFollowing(h.fileSystem)
+	if err != nil {
+		return nil, err
+	}
+	cl := newCreatorList(impressions, following)
+	if notFollowing {
+		cl.creators = slices.DeleteFunc(cl.creators, func(c creator) bool {
+			return c.Following
+		})
+	}
+	cl.Sort(opts.SortBy, opts.Order)
+	cl.Paginate(opts)
+	return instagram.Output(cl, opts)
+}
+
+func (h *handler) Daily(opts *instagram.Options) (*string, error) {
+	impressions, err := h.loadImpressions()
+	if err != nil {
+		return nil, err
+	}
+	dl := newDayList(impressions)
+	dl.Sort(opts.SortBy, opts.Order)
+	dl.Paginate(opts)
+	return instagram.Output(dl, opts)
+}
+
+type impressionFile struct {
+	path string
+	kind string
+}
+
+func (h *handler) loadImpressions() ([]impression, error) {
+	files := []impressionFile{
+		{path: instagram.PathPostsViewed, kind: kindPost},
+		{path: instagram.PathVideosWatched, kind: kindVideo},
+		{path: instagram.PathAdsViewed, kind: kindAd},
+		{path: instagram.PathSuggestedProfiles, kind: kindSuggested},
+	}
+	impressions := make([]impression, 0)
+	found := false
+	for _, file := range files {
+		data, err := h.fileSystem.ReadFile(file.path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		parsed, err := parseImpressions(data, file.kind)
+		if err != nil {
+			return nil, err
+		}
+		impressions = append(impressions, parsed...)
+	}
+	if !found {
+		return nil, instagram.MissingDataError(h.fileSystem, instagram.PathPostsViewed)
+	}
+	return impressions, nil
+}
+
+func parseImpressions(data []byte, kind string) ([]impression, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	impressions := make([]impression, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			author := entry.StringMapData[keyAuthor].Value
+			if author == "" {
+				author = entry.StringMapData[keyUsername].Value
+			}
+			impressions = append(impressions, impression{
+				kind:   kind,
+				author: author,
+				time:   time.Unix(entry.StringMapData[keyTime].Timestamp, 0),
+			})
+		}
+	}
+	return impressions, nil
+}
+
+type impression struct {
+	kind   string
+	author string
+	time   time.Time
+}
+
+type counts struct {
+	Posts     int `json:"posts" yaml:"posts"`
+	Videos    int `json:"videos" yaml:"videos"`
+	Ads       int `json:"ads" yaml:"ads"`
+	Suggested int `json:"suggested" yaml:"suggested"`
+	Total     int `json:"total" yaml:"total"`
+}
+
+func (c *counts) add(kind string) {
+	switch kind {
+	case kindPost:
+		c.Posts++
+	case kindVideo:
+		c.Videos++
+	case kindAd:
+		c.Ads++
+	case kindSuggested:
+		c.Suggested++
+	}
+	c.Total++
+}
+
+func (c *counts) row() table.Row {
+	return table.Row{
+		c.Posts,
+		c.Videos,
+		c.Ads,
+		c.Suggested,
+		c.Total,
+	}
+}
+
+func countsHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderPosts,
+		instagram.TableHeaderVideos,
+		instagram.TableHeaderAds,
+		instagram.TableHeaderSuggested,
+		instagram.TableHeaderTotal,
+	}
+}
+
+type creator struct {
+	Username   string `json:"username" yaml:"username"`
+	ProfileUrl string `json:"profileUrl" yaml:"profileUrl"`
+	counts     `yaml:",inline"`
+	Following  bool                 `json:"following" yaml:"following"`
+	LastSeen   *instagram.Timestamp `json:"lastSeen" yaml:"lastSeen"`
+}
+
+type creatorList struct {
+	creators []creator
+}
+
+func newCreatorList(impressions []impression, following *sdk.AccountSet) *creatorList {
+	cl := &creatorList{
+		creators: make([]creator, 0),
+	}
+	indexes := make(map[string]int)
+	for i := range impressions {
+		current := impressions[i]
+		if current.author == "" {
+			continue
+		}
+		key := strings.ToLower(current.author)
+		index, ok := indexes[key]
+		if !ok {
+			index = len(cl.creators)
+			indexes[key] = index
+			cl.creators = append(cl.creators, creator{
+				Username:   current.author,
+				ProfileUrl: fmt.Sprintf(instagram.ProfileUrlFormat, current.author),
+				Following:  following.Contains(current.author),
+				LastSeen:   &instagram.Timestamp{Time: current.time},
+			})
+		}
+		cl.creators[index].add(current.kind)
+		if current.time.After(cl.creators[index].LastSeen.Time) {
+			cl.creators[index].LastSeen = &instagram.Timestamp{Time: current.time}
+		}
+	}
+	return cl
+}
+
+func (cl *creatorList) Data() any {
+	return cl.creators
+}
+
+func (cl *creatorList) TableHeader() table.Row {
+	header := table.Row{
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderProfileUrl,
+	}
+	header = append(header, countsHeader()...)
+	return append(header, instagram.TableHeaderFollowing, instagram.TableHeaderLastSeen)
+}
+
+func (cl *creatorList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range cl.creators {
+		current := cl.creators[i]
+		row := table.Row{
+			current.Username,
+			current.ProfileUrl,
+		}
+		row = append(row, current.row()...)
+		rows = append(rows, append(row, current.Following, current.LastSeen))
+	}
+	return rows
+}
+
+func (cl *creatorList) Sort(field string, order string) {
+	sort.SliceStable(cl.creators, func(a, b int) bool {
+		creatorOne := cl.creators[a]
+		creatorTwo := cl.creators[b]
+		switch field {
+		case instagram.FieldTimestamp:
+			return creatorOne.LastSeen.Time.Before(creatorTwo.LastSeen.Time)
+		case instagram.FieldUsername:
+			return creatorOne.Username < creatorTwo.Username
+		default:
+			return creatorOne.Total < creatorTwo.Total
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(cl.creators)
+	}
+}
+
+func (cl *creatorList) Paginate(opts *instagram.Options) {
+	cl.creators = instagram.Paginate(cl.creators, opts)
+}
+
+type day struct {
+	Day    string `json:"day" yaml:"day"`
+	counts `yaml:",inline"`
+}
+
+type dayList struct {
+	days []day
+}
+
+func newDayList(impressions []impression) *dayList {
+	dl := &dayList{
+		days: make([]day, 0),
+	}
+	indexes := make(map[string]int)
+	for i := range impressions {
+		current := impressions[i]
+		key := current.time.Format(time.DateOnly)
+		index, ok := indexes[key]
+		if !ok {
+			index = len(dl.days)
+			indexes[key] = index
+			dl.days = append(dl.days, day{
+				Day: key,
+			})
+		}
+		dl.days[index].add(current.kind)
+	}
+	return dl
+}
+
+func (dl *dayList) Data() any {
+	return dl.days
+}
+
+func (dl *dayList) TableHeader() table.Row {
+	return append(table.Row{instagram.TableHeaderDay}, countsHeader()...)
+}
+
+func (dl *dayList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range dl.days {
+		current := dl.days[i]
+		rows = append(rows, append(table.Row{current.Day}, current.row()...))
+	}
+	return rows
+}
+
+func (dl *dayList) Sort(field string, order string) {
+	sort.SliceStable(dl.days, func(a, b int) bool {
+		dayOne := dl.days[a]
+		dayTwo := dl.days[b]
+		switch field {
+		case instagram.FieldCount:
+			return dayOne.Total < dayTwo.Total
+		default:
+			return dayOne.Day < dayTwo.Day
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(dl.days)
+	}
+}
+
+func (dl *dayList) Paginate(opts *instagram.Options) {
+	dl.days = instagram.Paginate(dl.days, opts)
+}
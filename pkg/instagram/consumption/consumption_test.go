@@ -0,0 +1,207 @@
+package consumption
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	postsViewedJson       = `{"impressions_history_posts_seen":[{"string_map_data":{"Author":{"value":"username1"},"Time":{"timestamp":1696161600}}},{"string_map_data":{"Author":{"value":"username2"},"Time":{"timestamp":1696248000}}}]}`
+	videosWatchedJson     = `{"impressions_history_videos_watched":[{"string_map_data":{"Author":{"value":"username1"},"Time":{"timestamp":1696165200}}}]}`
+	suggestedProfilesJson = `{"impressions_history_chaining_seen":[{"string_map_data":{"Username":{"value":"username3"},"Time":{"timestamp":1696248000}}}]}`
+	followingJson         = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]}`
+)
+
+func expectImpressions(f *filesystem.MockFs) {
+	f.On("ReadFile", instagram.PathPostsViewed).Return([]byte(postsViewedJson), nil)
+	f.On("ReadFile", instagram.PathVideosWatched).Return([]byte(videosWatchedJson), nil)
+	f.On("ReadFile", instagram.PathAdsViewed).Return(nil, fs.ErrNotExist)
+	f.On("ReadFile", instagram.PathSuggestedProfiles).Return([]byte(suggestedProfilesJson), nil)
+}
+
+func Test_handler_Creators(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output creators",
+			expectations: func(f *fields) {
+				expectImpressions(f.fileSystem)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 4)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPostsViewed).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse impressions",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPostsViewed).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load following",
+			expectations: func(f *fields) {
+				expectImpressions(f.fileSystem)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 4)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Creators(instagram.NewEmptyOptions(), true); (err != nil) != tt.wantErr {
+				t.Errorf("Creators() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Daily(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output daily consumption",
+			expectations: func(f *fields) {
+				expectImpressions(f.fileSystem)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 4)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to load impressions",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPostsViewed).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to find impressions",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 4)
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Daily(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Daily() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_newCreatorList(t *testing.T) {
+	posts, err := parseImpressions([]byte(postsViewedJson), kindPost)
+	assert.NoError(t, err)
+	videos, err := parseImpressions([]byte(videosWatchedJson), kindVideo)
+	assert.NoError(t, err)
+	suggested, err := parseImpressions([]byte(suggestedProfilesJson), kindSuggested)
+	assert.NoError(t, err)
+	impressions := append(append(posts, videos...), suggested...)
+	cl := newCreatorList(impressions, sdk.NewUsernameSet([]string{"username1"}))
+	cl.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, 3, len(cl.creators))
+	assert.Equal(t, "username1", cl.creators[0].Username)
+	assert.Equal(t, counts{Posts: 1, Videos: 1, Total: 2}, cl.creators[0].counts)
+	assert.True(t, cl.creators[0].Following)
+	assert.Equal(t, int64(1696165200), cl.creators[0].LastSeen.Unix())
+	output, err := instagram.Output(cl, &instagram.Options{Output: instagram.OutputYaml})
+	assert.NoError(t, err)
+	assert.Contains(t, *output, "  posts: 1\n")
+	output, err = instagram.Output(cl, &instagram.Options{Output: instagram.OutputJson})
+	assert.NoError(t, err)
+	assert.Contains(t, *output, `"videos": 1`)
+}
+
+func Test_newDayList(t *testing.T) {
+	posts, err := parseImpressions([]byte(postsViewedJson), kindPost)
+	assert.NoError(t, err)
+	suggested, err := parseImpressions([]byte(suggestedProfilesJson), kindSuggested)
+	assert.NoError(t, err)
+	dl := newDayList(append(posts, suggested...))
+	dl.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, 2, len(dl.days))
+	assert.Equal(t, 2, dl.days[0].Total)
+	dl.Sort(instagram.FieldTimestamp, instagram.OrderAsc)
+	assert.True(t, dl.days[0].Day < dl.days[1].Day)
+	dl.Paginate(&instagram.Options{Limit: 1})
+	assert.Equal(t, 1, len(dl.days))
+}
@@ -0,0 +1,210 @@
+package contacts
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	keyContactInformation = "Contact Information"
+	keyFirstName          = "First Name"
+	keySurname            = "Surname"
+	minMatchLength        = 3
+)
+
+type Interface interface {
+	Contacts(opts *instagram.Options, matched bool, reveal bool) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Contacts(opts *instagram.Options, matched bool, reveal bool) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathSyncedContacts)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathSyncedContacts, err)
+	}
+	cl, err := parseContacts(data, reveal)
+	if err != nil {
+		return nil, err
+	}
+	followers, err := followdata.LoadFollowers(h.fileSystem)
+	if err != nil {
+		return nil, err
+	}
+	following, err := followdata.LoadFollowing(h.fileSystem)
+	if err != nil {
+		return nil, err
+	}
+	cl.hydrateMatches(followers, following)
+	if matched {
+		cl.contacts = slices.DeleteFunc(cl.contacts, func(c contact) bool {
+			return len(c.Followers) == 0 && len(c.Following) == 0
+		})
+	}
+	cl.Sort(opts.SortBy, opts.Order)
+	cl.Paginate(opts)
+	return instagram.Output(cl, opts)
+}
+
+func parseContacts(data []byte, reveal bool) (*contactList, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	cl := &contactList{
+		contacts: make([]contact, 0),
+	}
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			firstName := entry.StringMapData[keyFirstName].Value
+			surname := entry.StringMapData[keySurname].Value
+			contactInformation := entry.StringMapData[keyContactInformation].Value
+			if !reveal {
+				contactInformation = instagram.Redact(contactInformation)
+			}
+			cl.contacts = append(cl.contacts, contact{
+				Name:               strings.TrimSpace(firstName + " " + surname),
+				ContactInformation: contactInformation,
+				Followers:          make([]string, 0),
+				Following:          make([]string, 0),
+				candidates:         matchCandidates(firstName, surname),
+			})
+		}
+	}
+	return cl, nil
+}
+
+func normalize(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, value)
+}
+
+func matchCandidates(firstName string, surname string) []string {
+	first, last := normalize(firstName), normalize(surname)
+	candidates := make([]string, 0, 2)
+	for _, candidate := range []string{first + last, last + first} {
+		if len(candidate) >= minMatchLength && !slices.Contains(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+type matchTarget struct {
+	username   string
+	normalized string
+}
+
+func newMatchTargets(accounts *sdk.AccountSet) []matchTarget {
+	targets := make([]matchTarget, 0, accounts.Len())
+	accounts.Each(func(username string, _ sdk.Account) {
+		targets = append(targets, matchTarget{
+			username:   username,
+			normalized: normalize(username),
+		})
+	})
+	return targets
+}
+
+func findMatches(candidates []string, targets []matchTarget) []string {
+	matches := make([]string, 0)
+	for i := range targets {
+		for _, candidate := range candidates {
+			if strings.Contains(targets[i].normalized, candidate) {
+				matches = append(matches, targets[i].username)
+				break
+			}
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+type contact struct {
+	Name               string   `json:"name" yaml:"name"`
+	ContactInformation string   `json:"contactInformation" yaml:"contactInformation"`
+	Followers          []string `json:"followers" yaml:"followers"`
+	Following          []string `json:"following" yaml:"following"`
+	candidates         []string
+}
+
+type contactList struct {
+	contacts []contact
+}
+
+func (cl *contactList) hydrateMatches(followers *sdk.AccountSet, following *sdk.AccountSet) {
+	followerTargets := newMatchTargets(followers)
+	followingTargets := newMatchTargets(following)
+	for i := range cl.contacts {
+		cl.contacts[i].Followers = findMatches(cl.contacts[i].candidates, followerTargets)
+		cl.contacts[i].Following = findMatches(cl.contacts[i].candidates, followingTargets)
+	}
+}
+
+func (cl *contactList) Data() any {
+	return cl.contacts
+}
+
+func (cl *contactList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderName,
+		instagram.TableHeaderContact,
+		instagram.TableHeaderFollowers,
+		instagram.TableHeaderFollowing,
+	}
+}
+
+func (cl *contactList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range cl.contacts {
+		current := cl.contacts[i]
+		rows = append(rows, table.Row{
+			current.Name,
+			current.ContactInformation,
+			strings.Join(current.Followers, ", "),
+			strings.Join(current.Following, ", "),
+		})
+	}
+	return rows
+}
+
+func (cl *contactList) Sort(field string, order string) {
+	sort.SliceStable(cl.contacts, func(a, b int) bool {
+		contactOne := cl.contacts[a]
+		contactTwo := cl.contacts[b]
+		switch field {
+		case instagram.FieldCount:
+			return len(contactOne.Followers)+len(contactOne.Following) < len(contactTwo.Followers)+len(contactTwo.Following)
+		default:
+			return strings.ToLower(contactOne.Name) < strings.ToLower(contactTwo.Name)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(cl.contacts)
+	}
+}
+
+func (cl *contactList) Paginate(opts *instagram.Options) {
+	cl.contacts = instagram.Paginate(cl.contacts, opts)
+}
@@ -0,0 +1,170 @@
+package contacts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	syncedContactsJson = `{"contacts_contact_info":[{"title":"","string_map_data":{"First Name":{"value":"John"},"Surname":{"value":"Doe"},"Contact Information":{"value":"+10000000000"}}},{"title":"","string_map_data":{"First Name":{"value":"Al"},"Surname":{"value":""},"Contact Information":{"value":"+10000000001"}}}]}`
+	followersJson      = `[{"string_list_data":[{"href":"https://www.instagram.com/john.doe","value":"john.doe","timestamp":0}]}]`
+	followingJson      = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/doe_john_99","value":"doe_john_99","timestamp":0}]},{"string_list_data":[{"href":"https://www.instagram.com/alice","value":"alice","timestamp":0}]}]}`
+)
+
+func Test_handler_Contacts(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output contacts",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSyncedContacts).Return([]byte(syncedContactsJson), nil)
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSyncedContacts).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse contacts",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSyncedContacts).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load followers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSyncedContacts).Return([]byte(syncedContactsJson), nil)
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load following",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSyncedContacts).Return([]byte(syncedContactsJson), nil)
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Contacts(instagram.NewEmptyOptions(), true, false); (err != nil) != tt.wantErr {
+				t.Errorf("Contacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_contactList_hydrateMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal bool
+		want   []contact
+	}{
+		{
+			name:   "matches and redacts contacts",
+			reveal: false,
+			want: []contact{
+				{
+					Name:               "Al",
+					ContactInformation: instagram.RedactedValue,
+					Followers:          []string{},
+					Following:          []string{},
+					candidates:         []string{},
+				},
+				{
+					Name:               "John Doe",
+					ContactInformation: instagram.RedactedValue,
+					Followers:          []string{"john.doe"},
+					Following:          []string{"doe_john_99"},
+					candidates:         []string{"johndoe", "doejohn"},
+				},
+			},
+		},
+		{
+			name:   "matches and reveals contacts",
+			reveal: true,
+			want: []contact{
+				{
+					Name:               "Al",
+					ContactInformation: "+10000000001",
+					Followers:          []string{},
+					Following:          []string{},
+					candidates:         []string{},
+				},
+				{
+					Name:               "John Doe",
+					ContactInformation: "+10000000000",
+					Followers:          []string{"john.doe"},
+					Following:          []string{"doe_john_99"},
+					candidates:         []string{"johndoe", "doejohn"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := parseContacts([]byte(syncedContactsJson), tt.reveal)
+			assert.NoError(t, err)
+			cl.hydrateMatches(sdk.NewUsernameSet([]string{"john.doe"}), sdk.NewUsernameSet([]string{"doe_john_99", "alice"}))
+			cl.Sort(instagram.FieldName, instagram.OrderAsc)
+			assert.Equal(t, tt.want, cl.contacts)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package instagram
+
+type StringData struct {
+	Href      string `json:"href"`
+	Value     string `json:"value"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type Entry struct {
+	Title          string                `json:"title"`
+	StringListData []StringData          `json:"string_list_data"`
+	StringMapData  map[string]StringData `json:"string_map_data"`
+}
@@ -0,0 +1,97 @@
+package instagram
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+)
+
+const (
+	ExitCodeFailure         = 1
+	ExitCodeDataNotLoaded   = 2
+	ExitCodeCategoryMissing = 3
+)
+
+var (
+	ErrDataNotLoaded   = errors.New(`instagram data not loaded, run "instagram information load <source>" first`)
+	ErrCategoryMissing = errors.New("instagram data category missing")
+)
+
+var categories = map[string]string{
+	PathAccountInformation:     "Personal information",
+	PathAccountLocation:        "Information about you",
+	PathAccountSearches:        "Recent searches",
+	PathAdsAdvertisers:         "Ads information",
+	PathAdsInterests:           "Ads information",
+	PathAdsViewed:              "Ads information",
+	PathFollowers:              "Followers and following",
+	PathFollowing:              "Followers and following",
+	PathKeywordSearches:        "Recent searches",
+	PathLikedPosts:             "Likes",
+	PathLocationsOfInterest:    "Information about you",
+	PathLoginActivity:          "Login and account creation",
+	PathLogoutActivity:         "Login and account creation",
+	PathMessages:               "Messages",
+	PathOffMetaActivity:        "Apps and websites off of Instagram",
+	PathPasswordChangeActivity: "Login and account creation",
+	PathPersonalInformation:    "Personal information",
+	PathPostComments:           "Comments",
+	PathPosts:                  "Posts",
+	PathPostsViewed:            "Ads information",
+	PathProfileChanges:         "Personal information",
+	PathSavedCollections:       "Saved",
+	PathSavedPosts:             "Saved",
+	PathSignupInformation:      "Login and account creation",
+	PathStories:                "Stories",
+	PathStoryStickers:          "Story sticker interactions",
+	PathSuggestedProfiles:      "Ads information",
+	PathSyncedContacts:         "Contacts",
+	PathTagSearches:            "Recent searches",
+	PathVideosWatched:          "Ads information",
+}
+
+type CategoryMissingError struct {
+	Path     string
+	Category string
+}
+
+func (e *CategoryMissingError) Error() string {
+	if e.Category == "" {
+		return fmt.Sprintf("%s: %s not found", ErrCategoryMissing, e.Path)
+	}
+	return fmt.Sprintf(`%s: %s not found, select "%s" in Instagram's download tool and load your data again`, ErrCategoryMissing, e.Path, e.Category)
+}
+
+func (e *CategoryMissingError) Is(target error) bool {
+	return target == ErrCategoryMissing || target == fs.ErrNotExist
+}
+
+func MissingDataError(fileSystem filesystem.Fs, pattern string) error {
+	if files, err := fileSystem.FindFiles(PathData); err == nil && len(files) == 0 {
+		return ErrDataNotLoaded
+	}
+	return &CategoryMissingError{
+		Path:     pattern,
+		Category: categories[pattern],
+	}
+}
+
+func WrapNotExist(fileSystem filesystem.Fs, pattern string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return MissingDataError(fileSystem, pattern)
+	}
+	return err
+}
+
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrDataNotLoaded):
+		return ExitCodeDataNotLoaded
+	case errors.Is(err, ErrCategoryMissing):
+		return ExitCodeCategoryMissing
+	default:
+		return ExitCodeFailure
+	}
+}
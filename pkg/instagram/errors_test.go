@@ -0,0 +1,116 @@
+package instagram
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryMissingError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *CategoryMissingError
+		want string
+	}{
+		{
+			name: "succeeds to include download tool hint",
+			err:  &CategoryMissingError{Path: PathFollowing, Category: "Followers and following"},
+			want: `instagram data category missing: instagram_data/connections/followers_and_following/following.json not found, select "Followers and following" in Instagram's download tool and load your data again`,
+		},
+		{
+			name: "succeeds to omit hint for unknown category",
+			err:  &CategoryMissingError{Path: "unknown.json"},
+			want: "instagram data category missing: unknown.json not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
+
+func TestWrapNotExist(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectations func(f *filesystem.MockFs)
+		wantErrs     []error
+		wantNil      bool
+	}{
+		{
+			name: "succeeds to wrap missing category",
+			err:  fs.ErrNotExist,
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", PathData).Return([]string{PathData}, nil)
+			},
+			wantErrs: []error{ErrCategoryMissing, fs.ErrNotExist},
+		},
+		{
+			name: "succeeds to wrap data not loaded",
+			err:  fs.ErrNotExist,
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", PathData).Return([]string{}, nil)
+			},
+			wantErrs: []error{ErrDataNotLoaded},
+		},
+		{
+			name:         "succeeds to pass through other errors",
+			err:          fs.ErrPermission,
+			expectations: func(f *filesystem.MockFs) {},
+			wantErrs:     []error{fs.ErrPermission},
+		},
+		{
+			name:         "succeeds to pass through nil",
+			err:          nil,
+			expectations: func(f *filesystem.MockFs) {},
+			wantNil:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			tt.expectations(f)
+			err := WrapNotExist(f, PathFollowing, tt.err)
+			if tt.wantNil {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErrs {
+				assert.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "data not loaded",
+			err:  fmt.Errorf("wrapped: %w", ErrDataNotLoaded),
+			want: ExitCodeDataNotLoaded,
+		},
+		{
+			name: "category missing",
+			err:  &CategoryMissingError{Path: PathFollowing},
+			want: ExitCodeCategoryMissing,
+		},
+		{
+			name: "other failure",
+			err:  fmt.Errorf("failure"),
+			want: ExitCodeFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
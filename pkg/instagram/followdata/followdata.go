@@ -1,233 +1,175 @@
 package followdata
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"slices"
 	"sort"
-	"strconv"
-	"time"
+	"sync"
 
 	"github.com/cecobask/instagram-insights/pkg/filesystem"
 	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
 	"github.com/jedib0t/go-pretty/v6/table"
-	"gopkg.in/yaml.v3"
 )
 
 type Interface interface {
-	Followers(opts *instagram.Options) (*string, error)
-	Following(opts *instagram.Options) (*string, error)
-	Unfollowers(opts *instagram.Options) (*string, error)
+	Followers(ctx context.Context, opts *instagram.Options) (*string, error)
+	Following(ctx context.Context, opts *instagram.Options) (*string, error)
+	Inactive(ctx context.Context, opts *instagram.Options, since string) (*string, error)
+	Relationships(ctx context.Context, opts *instagram.Options, weights string) (*string, error)
+	Unfollowers(ctx context.Context, opts *instagram.Options) (*string, error)
 }
 
 type handler struct {
 	fileSystem filesystem.Fs
-	followData *followData
+	sdkLoader  *sdk.Loader
+	loaderOnce sync.Once
 }
 
-func NewHandler() Interface {
+func NewHandler(fileSystem filesystem.Fs) Interface {
 	return &handler{
-		fileSystem: filesystem.NewFs(),
-		followData: newFollowData(),
+		fileSystem: fileSystem,
 	}
 }
 
-func (h *handler) Followers(opts *instagram.Options) (*string, error) {
-	files, err := h.fileSystem.FindFiles(instagram.PathFollowers)
+func (h *handler) loader() *sdk.Loader {
+	h.loaderOnce.Do(func() {
+		h.sdkLoader = sdk.NewLoaderWithFs(h.fileSystem)
+	})
+	return h.sdkLoader
+}
+
+func (h *handler) Followers(ctx context.Context, opts *instagram.Options) (*string, error) {
+	followers, err := h.loader().Followers(ctx)
 	if err != nil {
 		return nil, err
 	}
-	for i := range files {
-		data, err := h.fileSystem.ReadFile(files[i])
-		if err != nil {
-			return nil, err
-		}
-		if err = h.followData.hydrateFollowers(data); err != nil {
-			return nil, err
-		}
-	}
-	h.followData.Followers.Sort(opts.SortBy, opts.Order)
-	h.followData.Followers.Limit(opts.Limit)
-	return h.followData.Followers.output(opts.Output)
+	return render(newUserList(followers, true), opts)
 }
 
-func (h *handler) Following(opts *instagram.Options) (*string, error) {
-	data, err := h.fileSystem.ReadFile(instagram.PathFollowing)
+func (h *handler) Following(ctx context.Context, opts *instagram.Options) (*string, error) {
+	following, err := h.loader().Following(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err = h.followData.hydrateFollowing(data); err != nil {
-		return nil, err
-	}
-	h.followData.Following.Sort(opts.SortBy, opts.Order)
-	h.followData.Following.Limit(opts.Limit)
-	return h.followData.Following.output(opts.Output)
+	return render(newUserList(following, true), opts)
 }
 
-func (h *handler) Unfollowers(opts *instagram.Options) (*string, error) {
-	emptyOptions := instagram.NewEmptyOptions()
-	if _, err := h.Followers(emptyOptions); err != nil {
-		return nil, err
-	}
-	if _, err := h.Following(emptyOptions); err != nil {
+func (h *handler) Unfollowers(ctx context.Context, opts *instagram.Options) (*string, error) {
+	unfollowers, err := h.loader().Unfollowers(ctx)
+	if err != nil {
 		return nil, err
 	}
-	h.followData.hydrateUnfollowers()
-	h.followData.Unfollowers.Sort(opts.SortBy, opts.Order)
-	h.followData.Unfollowers.Limit(opts.Limit)
-	return h.followData.Unfollowers.output(opts.Output)
+	return render(newUserList(unfollowers, false), opts)
 }
 
-type followData struct {
-	Following   *userList
-	Followers   *userList
-	Unfollowers *userList
-}
-
-func newFollowData() *followData {
-	return &followData{
-		Following:   newUserList(true),
-		Followers:   newUserList(true),
-		Unfollowers: newUserList(false),
+func LoadFollowers(fileSystem filesystem.Fs) (*sdk.AccountSet, error) {
+	followers, err := sdk.NewLoaderWithFs(fileSystem).Followers(context.Background())
+	if err != nil {
+		return nil, err
 	}
+	return sdk.NewAccountSet(followers), nil
 }
 
-type userData struct {
-	UserData []userOriginal `json:"string_list_data"`
-}
-
-func (fd *followData) hydrateFollowers(data []byte) error {
-	var jsonData []userData
-	if err := json.Unmarshal(data, &jsonData); err != nil {
-		return err
-	}
-	for i := range jsonData {
-		ud := jsonData[i].UserData[0]
-		fd.Followers.Append(user{
-			ProfileUrl: ud.Href,
-			Username:   ud.Value,
-			Timestamp: &timestamp{
-				Time: time.Unix(int64(ud.Timestamp), 0),
-			},
-		})
+func LoadFollowing(fileSystem filesystem.Fs) (*sdk.AccountSet, error) {
+	following, err := sdk.NewLoaderWithFs(fileSystem).Following(context.Background())
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return sdk.NewAccountSet(following), nil
 }
 
-func (fd *followData) hydrateFollowing(data []byte) error {
-	jsonData := make(map[string][]userData)
-	if err := json.Unmarshal(data, &jsonData); err != nil {
-		return err
-	}
-	for i := range jsonData["relationships_following"] {
-		ud := jsonData["relationships_following"][i].UserData[0]
-		fd.Following.Append(user{
-			ProfileUrl: ud.Href,
-			Username:   ud.Value,
-			Timestamp: &timestamp{
-				Time: time.Unix(int64(ud.Timestamp), 0),
-			},
-		})
+func LoadFollowerEvents(fileSystem filesystem.Fs) ([]activity.Event, error) {
+	followers, err := sdk.NewLoaderWithFs(fileSystem).Followers(context.Background())
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return accountEvents(activity.CategoryFollower, followers), nil
 }
 
-func (fd *followData) hydrateUnfollowers() {
-	for i := range fd.Following.users {
-		current := fd.Following.users[i]
-		index := slices.IndexFunc(fd.Followers.users, func(u user) bool {
-			return u.Username == current.Username
-		})
-		if index == -1 {
-			fd.Unfollowers.Append(current)
-		}
+func LoadFollowingEvents(fileSystem filesystem.Fs) ([]activity.Event, error) {
+	following, err := sdk.NewLoaderWithFs(fileSystem).Following(context.Background())
+	if err != nil {
+		return nil, err
 	}
+	return accountEvents(activity.CategoryFollowing, following), nil
 }
 
-type timestamp struct {
-	time.Time
-}
-
-func (t *timestamp) MarshalJSON() ([]byte, error) {
-	return []byte(strconv.Quote(t.String())), nil
-}
-
-func (t *timestamp) UnmarshalJSON(b []byte) error {
-	var unixTimestamp int64
-	if err := json.Unmarshal(b, &unixTimestamp); err != nil {
-		return err
+func accountEvents(category string, accounts []sdk.Account) []activity.Event {
+	events := make([]activity.Event, 0, len(accounts))
+	for _, a := range accounts {
+		events = append(events, activity.Event{
+			Category:  category,
+			Username:  a.Username,
+			Timestamp: a.Timestamp,
+		})
 	}
-	t.Time = time.Unix(unixTimestamp, 0)
-	return nil
-}
-
-func (t *timestamp) MarshalYAML() (interface{}, error) {
-	return t.String(), nil
+	return events
 }
 
-func (t *timestamp) String() string {
-	return t.Format(time.RFC3339)
-}
-
-type userOriginal struct {
-	Href      string `json:"href"`
-	Value     string `json:"value"`
-	Timestamp int    `json:"timestamp"`
+func render(ul *userList, opts *instagram.Options) (*string, error) {
+	ul.Sort(opts.SortBy, opts.Order)
+	ul.Paginate(opts)
+	return ul.output(opts)
 }
 
 type user struct {
-	ProfileUrl string     `json:"profileUrl" yaml:"profileUrl"`
-	Username   string     `json:"username" yaml:"username"`
-	Timestamp  *timestamp `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	ProfileUrl string               `json:"profileUrl" yaml:"profileUrl"`
+	Username   string               `json:"username" yaml:"username"`
+	Timestamp  *instagram.Timestamp `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Engagement *engagement          `json:"engagement,omitempty" yaml:"engagement,omitempty"`
 }
 
 type userList struct {
-	users         []user
-	showTimestamp bool
+	users          []user
+	showTimestamp  bool
+	showEngagement bool
 }
 
-func newUserList(showTimestamp bool) *userList {
-	return &userList{
-		users:         make([]user, 0),
+func newUserList(accounts []sdk.Account, showTimestamp bool) *userList {
+	ul := &userList{
+		users:         make([]user, 0, len(accounts)),
 		showTimestamp: showTimestamp,
 	}
-}
-
-func (ul *userList) output(format string) (*string, error) {
-	switch format {
-	case instagram.OutputJson:
-		return ul.outputJson()
-	case instagram.OutputNone:
-		return ul.outputNone()
-	case instagram.OutputTable:
-		return ul.outputTable()
-	case instagram.OutputYaml:
-		return ul.outputYaml()
-	default:
-		return nil, fmt.Errorf("invalid output format: %s", format)
+	for i := range accounts {
+		ul.Append(user{
+			ProfileUrl: accounts[i].ProfileUrl,
+			Username:   accounts[i].Username,
+			Timestamp:  &instagram.Timestamp{Time: accounts[i].Timestamp},
+		})
 	}
+	return ul
 }
 
-func (ul *userList) outputNone() (*string, error) {
-	output := ""
-	return &output, nil
+func (ul *userList) output(opts *instagram.Options) (*string, error) {
+	return instagram.Output(ul, opts)
 }
 
-func (ul *userList) outputJson() (*string, error) {
+func (ul *userList) Data() any {
 	if !ul.showTimestamp {
 		for i := range ul.users {
 			ul.users[i].Timestamp = nil
 		}
 	}
-	data, err := json.MarshalIndent(ul.users, "", "  ")
-	if err != nil {
-		return nil, err
+	return ul.users
+}
+
+func (ul *userList) TableHeader() table.Row {
+	header := table.Row{
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderProfileUrl,
 	}
-	output := string(data)
-	return &output, nil
+	if ul.showTimestamp {
+		header = append(header, instagram.TableHeaderTimestamp)
+	}
+	if ul.showEngagement {
+		header = append(header, engagementHeader()...)
+	}
+	return header
 }
 
-func (ul *userList) outputTable() (*string, error) {
+func (ul *userList) TableRows() []table.Row {
 	var rows []table.Row
 	for i := range ul.users {
 		current := ul.users[i]
@@ -238,43 +180,21 @@ func (ul *userList) outputTable() (*string, error) {
 		if ul.showTimestamp {
 			row = append(row, current.Timestamp)
 		}
-		rows = append(rows, row)
-	}
-	header := table.Row{
-		instagram.TableHeaderUsername,
-		instagram.TableHeaderProfileUrl,
-	}
-	if ul.showTimestamp {
-		header = append(header, instagram.TableHeaderTimestamp)
-	}
-	usersTable := table.NewWriter()
-	usersTable.SetAutoIndex(true)
-	usersTable.SetStyle(table.StyleBold)
-	usersTable.AppendHeader(header)
-	usersTable.AppendRows(rows)
-	output := usersTable.Render()
-	return &output, nil
-}
-
-func (ul *userList) outputYaml() (*string, error) {
-	if !ul.showTimestamp {
-		for i := range ul.users {
-			ul.users[i].Timestamp = nil
+		if ul.showEngagement {
+			row = append(row, current.Engagement.row()...)
 		}
+		rows = append(rows, row)
 	}
-	data, err := yaml.Marshal(ul.users)
-	if err != nil {
-		return nil, err
-	}
-	output := string(data)
-	return &output, nil
+	return rows
 }
 
 func (ul *userList) Sort(field string, order string) {
-	sort.Slice(ul.users, func(a, b int) bool {
+	sort.SliceStable(ul.users, func(a, b int) bool {
 		userOne := ul.users[a]
 		userTwo := ul.users[b]
 		switch field {
+		case instagram.FieldScore:
+			return userOne.Engagement.less(userTwo.Engagement)
 		case instagram.FieldTimestamp:
 			return userOne.Timestamp.Time.Before(userTwo.Timestamp.Time)
 		case instagram.FieldUsername:
@@ -288,10 +208,8 @@ func (ul *userList) Sort(field string, order string) {
 	}
 }
 
-func (ul *userList) Limit(limit int) {
-	if limit > 0 && limit < len(ul.users) {
-		ul.users = ul.users[:limit]
-	}
+func (ul *userList) Paginate(opts *instagram.Options) {
+	ul.users = instagram.Paginate(ul.users, opts)
 }
 
 func (ul *userList) Append(u user) {
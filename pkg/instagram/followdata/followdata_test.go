@@ -1,19 +1,23 @@
 package followdata
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cecobask/instagram-insights/pkg/filesystem"
 	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_handler_Followers(t *testing.T) {
 	type fields struct {
 		fileSystem *filesystem.MockFs
-		followData *followData
 	}
 	tests := []struct {
 		name         string
@@ -25,11 +29,11 @@ func Test_handler_Followers(t *testing.T) {
 			name: "succeeds to output followers when single followers_n file is present",
 			expectations: func(f *fields) {
 				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return([]byte(`[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]`), nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]`)), nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: false,
 		},
@@ -40,12 +44,12 @@ func Test_handler_Followers(t *testing.T) {
 					"file1",
 					"file2",
 				}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return([]byte(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]`), nil)
-				f.fileSystem.On("ReadFile", "file2").Return([]byte(`[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":0}]}]`), nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]`)), nil)
+				f.fileSystem.On("Open", "file2").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":0}]}]`)), nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
 			},
 			wantErr: false,
 		},
@@ -63,11 +67,11 @@ func Test_handler_Followers(t *testing.T) {
 			name: "fails to read file",
 			expectations: func(f *fields) {
 				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return(nil, fmt.Errorf("fails to read file"))
+				f.fileSystem.On("Open", "file1").Return(nil, fmt.Errorf("fails to read file"))
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: true,
 		},
@@ -75,11 +79,11 @@ func Test_handler_Followers(t *testing.T) {
 			name: "fails to hydrate followers",
 			expectations: func(f *fields) {
 				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return([]byte(""), nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader("")), nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: true,
 		},
@@ -88,16 +92,14 @@ func Test_handler_Followers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := &fields{
 				fileSystem: &filesystem.MockFs{},
-				followData: newFollowData(),
 			}
 			h := &handler{
 				fileSystem: f.fileSystem,
-				followData: f.followData,
 			}
 			if tt.expectations != nil {
 				tt.expectations(f)
 			}
-			if _, err := h.Followers(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+			if _, err := h.Followers(context.Background(), instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
 				t.Errorf("Followers() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if tt.assertions != nil {
@@ -110,7 +112,6 @@ func Test_handler_Followers(t *testing.T) {
 func Test_handler_Following(t *testing.T) {
 	type fields struct {
 		fileSystem *filesystem.MockFs
-		followData *followData
 	}
 	tests := []struct {
 		name         string
@@ -121,30 +122,30 @@ func Test_handler_Following(t *testing.T) {
 		{
 			name: "succeeds to output following",
 			expectations: func(f *fields) {
-				f.fileSystem.On("ReadFile", instagram.PathFollowing).Return([]byte(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]}`), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]}`)), nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: false,
 		},
 		{
 			name: "fails to read file",
 			expectations: func(f *fields) {
-				f.fileSystem.On("ReadFile", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
 			},
 			assertions: func(t *testing.T, f *fields) {
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "fails to hydrate following",
 			expectations: func(f *fields) {
-				f.fileSystem.On("ReadFile", instagram.PathFollowing).Return([]byte(""), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader("")), nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
 			},
 			wantErr: true,
 		},
@@ -153,16 +154,14 @@ func Test_handler_Following(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := &fields{
 				fileSystem: &filesystem.MockFs{},
-				followData: newFollowData(),
 			}
 			h := &handler{
 				fileSystem: f.fileSystem,
-				followData: f.followData,
 			}
 			if tt.expectations != nil {
 				tt.expectations(f)
 			}
-			if _, err := h.Following(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+			if _, err := h.Following(context.Background(), instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
 				t.Errorf("Following() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if tt.assertions != nil {
@@ -175,7 +174,6 @@ func Test_handler_Following(t *testing.T) {
 func Test_handler_Unfollowers(t *testing.T) {
 	type fields struct {
 		fileSystem *filesystem.MockFs
-		followData *followData
 	}
 	tests := []struct {
 		name         string
@@ -187,12 +185,12 @@ func Test_handler_Unfollowers(t *testing.T) {
 			name: "succeeds to output unfollowers",
 			expectations: func(f *fields) {
 				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return([]byte(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]`), nil).Once()
-				f.fileSystem.On("ReadFile", instagram.PathFollowing).Return([]byte(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":0}]}]}`), nil).Once()
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]`)), nil).Once()
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":0}]}]}`)), nil).Once()
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
 			},
 			wantErr: false,
 		},
@@ -210,12 +208,12 @@ func Test_handler_Unfollowers(t *testing.T) {
 			name: "fails to get following",
 			expectations: func(f *fields) {
 				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
-				f.fileSystem.On("ReadFile", "file1").Return([]byte(`[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]`), nil).Once()
-				f.fileSystem.On("ReadFile", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file")).Once()
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username","value":"username","timestamp":0}]}]`)), nil).Once()
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file")).Once()
 			},
 			assertions: func(t *testing.T, f *fields) {
 				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
-				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
 			},
 			wantErr: true,
 		},
@@ -224,16 +222,14 @@ func Test_handler_Unfollowers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := &fields{
 				fileSystem: &filesystem.MockFs{},
-				followData: newFollowData(),
 			}
 			h := &handler{
 				fileSystem: f.fileSystem,
-				followData: f.followData,
 			}
 			if tt.expectations != nil {
 				tt.expectations(f)
 			}
-			if _, err := h.Unfollowers(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+			if _, err := h.Unfollowers(context.Background(), instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
 				t.Errorf("Unfollowers() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if tt.assertions != nil {
@@ -243,6 +239,25 @@ func Test_handler_Unfollowers(t *testing.T) {
 	}
 }
 
+func Test_handler_loader(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+	f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1}]}]`)), nil).Once()
+	f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":2}]}]}`)), nil).Once()
+	h := &handler{
+		fileSystem: f,
+	}
+	opts := instagram.NewEmptyOptions()
+	_, err := h.Followers(context.Background(), opts)
+	assert.NoError(t, err)
+	_, err = h.Following(context.Background(), opts)
+	assert.NoError(t, err)
+	_, err = h.Unfollowers(context.Background(), opts)
+	assert.NoError(t, err)
+	f.AssertNumberOfCalls(t, "FindFiles", 1)
+	f.AssertNumberOfCalls(t, "Open", 2)
+}
+
 func Test_userList_output(t *testing.T) {
 	type args struct {
 		format string
@@ -250,7 +265,7 @@ func Test_userList_output(t *testing.T) {
 	dummyUser := user{
 		ProfileUrl: "https://www.instagram.com/username",
 		Username:   "username",
-		Timestamp:  &timestamp{},
+		Timestamp:  &instagram.Timestamp{},
 	}
 	u := userList{
 		users:         []user{dummyUser},
@@ -325,47 +340,13 @@ func Test_userList_output(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := tt.u.output(tt.args.format); (err != nil) != tt.wantErr {
+			if _, err := tt.u.output(&instagram.Options{Output: tt.args.format}); (err != nil) != tt.wantErr {
 				t.Errorf("output() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_timestamp_UnmarshalJSON(t *testing.T) {
-	type args struct {
-		b []byte
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "succeeds to unmarshal json",
-			args: args{
-				b: []byte("1697474963"),
-			},
-			wantErr: false,
-		},
-		{
-			name: "fails to unmarshal json",
-			args: args{
-				b: []byte("invalid"),
-			},
-			wantErr: true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &timestamp{}
-			if err := ts.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
-				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
 func Test_userList_Sort(t *testing.T) {
 	type fields struct {
 		users []user
@@ -379,14 +360,14 @@ func Test_userList_Sort(t *testing.T) {
 		{
 			ProfileUrl: "https://www.instagram.com/username1",
 			Username:   "username1",
-			Timestamp: &timestamp{
+			Timestamp: &instagram.Timestamp{
 				Time: timeNow,
 			},
 		},
 		{
 			ProfileUrl: "https://www.instagram.com/username2",
 			Username:   "username2",
-			Timestamp: &timestamp{
+			Timestamp: &instagram.Timestamp{
 				Time: timeNow.Add(time.Hour),
 			},
 		},
@@ -479,23 +460,23 @@ func Test_userList_Sort(t *testing.T) {
 	}
 }
 
-func Test_userList_Limit(t *testing.T) {
+func Test_userList_Paginate(t *testing.T) {
 	type fields struct {
 		users []user
 	}
 	type args struct {
-		limit int
+		opts *instagram.Options
 	}
 	testUsers := []user{
 		{
 			ProfileUrl: "https://www.instagram.com/username1",
 			Username:   "username1",
-			Timestamp:  &timestamp{},
+			Timestamp:  &instagram.Timestamp{},
 		},
 		{
 			ProfileUrl: "https://www.instagram.com/username2",
 			Username:   "username2",
-			Timestamp:  &timestamp{},
+			Timestamp:  &instagram.Timestamp{},
 		},
 	}
 	tests := []struct {
@@ -510,19 +491,32 @@ func Test_userList_Limit(t *testing.T) {
 				users: testUsers,
 			},
 			args: args{
-				limit: 1,
+				opts: &instagram.Options{Limit: 1},
 			},
 			assertions: func(t *testing.T, ul *userList) {
 				assert.Equal(t, 1, len(ul.users))
 			},
 		},
+		{
+			name: "succeeds to page users",
+			fields: fields{
+				users: testUsers,
+			},
+			args: args{
+				opts: &instagram.Options{Page: 2, PageSize: 1},
+			},
+			assertions: func(t *testing.T, ul *userList) {
+				assert.Equal(t, 1, len(ul.users))
+				assert.Equal(t, "username2", ul.users[0].Username)
+			},
+		},
 		{
 			name: "avoids to limit users when limit is unlimited",
 			fields: fields{
 				users: testUsers,
 			},
 			args: args{
-				limit: instagram.Unlimited,
+				opts: &instagram.Options{Limit: instagram.Unlimited},
 			},
 			assertions: func(t *testing.T, ul *userList) {
 				assert.Equal(t, 2, len(ul.users))
@@ -534,8 +528,154 @@ func Test_userList_Limit(t *testing.T) {
 			ul := &userList{
 				users: tt.fields.users,
 			}
-			ul.Limit(tt.args.limit)
+			ul.Paginate(tt.args.opts)
 			tt.assertions(t, ul)
 		})
 	}
 }
+
+func TestLoadFollowEvents(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+	f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1}]}]`)), nil)
+	f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":2}]}]}`)), nil)
+	events, err := LoadFollowerEvents(f)
+	assert.NoError(t, err)
+	assert.Equal(t, []activity.Event{
+		{Category: activity.CategoryFollower, Username: "username1", Timestamp: time.Unix(1, 0)},
+	}, events)
+	events, err = LoadFollowingEvents(f)
+	assert.NoError(t, err)
+	assert.Equal(t, []activity.Event{
+		{Category: activity.CategoryFollowing, Username: "username2", Timestamp: time.Unix(2, 0)},
+	}, events)
+	f = &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+	_, err = LoadFollowerEvents(f)
+	assert.Error(t, err)
+	f = &filesystem.MockFs{}
+	f.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+	_, err = LoadFollowingEvents(f)
+	assert.Error(t, err)
+}
+
+func TestLoadFollowers(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		want         *sdk.AccountSet
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load followers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"href":"https://www.instagram.com/UserName","value":"UserName","timestamp":0}]}]`)), nil)
+			},
+			want: sdk.NewAccountSet([]sdk.Account{
+				{Username: "UserName", ProfileUrl: "https://www.instagram.com/UserName", Timestamp: time.Unix(0, 0)},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "fails to find files",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.fileSystem.On("Open", "file1").Return(nil, fmt.Errorf("fails to read file"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to hydrate followers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.fileSystem.On("Open", "file1").Return(io.NopCloser(strings.NewReader("")), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := LoadFollowers(f.fileSystem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFollowers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadFollowing(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		want         *sdk.AccountSet
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load following",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(`{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/UserName","value":"UserName","timestamp":0}]}]}`)), nil)
+			},
+			want: sdk.NewAccountSet([]sdk.Account{
+				{Username: "UserName", ProfileUrl: "https://www.instagram.com/UserName", Timestamp: time.Unix(0, 0)},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to hydrate following",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader("")), nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := LoadFollowing(f.fileSystem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFollowing() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package followdata
+
+import (
+	"context"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+)
+
+func (h *handler) Inactive(ctx context.Context, opts *instagram.Options, since string) (*string, error) {
+	cutoff, err := instagram.ParseDate(since)
+	if err != nil {
+		return nil, err
+	}
+	following, err := h.loader().Following(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := sdk.NewUsernameSet(nil)
+	loaders := []func(fileSystem filesystem.Fs) ([]activity.Event, error){
+		activity.LoadLikes,
+		activity.LoadComments,
+		activity.LoadAccountSearches,
+	}
+	for _, load := range loaders {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		loaded, err := load(h.fileSystem)
+		if err != nil {
+			return nil, err
+		}
+		for i := range loaded {
+			markActive(active, loaded[i], cutoff)
+		}
+	}
+	err = activity.EachMessage(h.fileSystem, func(event activity.Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		markActive(active, event, cutoff)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return render(newInactiveList(following, active), opts)
+}
+
+func markActive(active *sdk.AccountSet, event activity.Event, cutoff time.Time) {
+	if event.Username == "" || event.Timestamp.Before(cutoff) {
+		return
+	}
+	active.Add(sdk.Account{Username: event.Username})
+}
+
+func newInactiveList(following []sdk.Account, active *sdk.AccountSet) *userList {
+	inactive := sdk.NewAccountSet(following).Difference(active)
+	return newUserList(inactive.Accounts(), true)
+}
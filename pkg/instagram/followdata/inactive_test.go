@@ -0,0 +1,143 @@
+package followdata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+const inactiveFollowingJson = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1}]},{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":2}]},{"string_list_data":[{"href":"https://www.instagram.com/username3","value":"username3","timestamp":3}]}]}`
+
+func Test_handler_Inactive(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		since        string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name:  "succeeds to output inactive following",
+			since: "2023-10-01",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(inactiveFollowingJson)), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(relationshipsLikesJson), nil)
+				f.fileSystem.On("FindFiles", instagram.PathPostComments).Return([]string{}, nil)
+				f.fileSystem.On("FindFiles", instagram.PathMessages).Return([]string{}, nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountSearches).Return([]byte(`{"searches_user":[]}`), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "fails to parse since date",
+			since:        "invalid",
+			expectations: nil,
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "fails to load following",
+			since: "",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "fails to load activity",
+			since: "",
+			expectations: func(f *fields) {
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(inactiveFollowingJson)), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(relationshipsLikesJson), nil)
+				f.fileSystem.On("FindFiles", instagram.PathPostComments).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Inactive(context.Background(), instagram.NewEmptyOptions(), tt.since); (err != nil) != tt.wantErr {
+				t.Errorf("Inactive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_newInactiveList(t *testing.T) {
+	following := []sdk.Account{
+		{Username: "username1", Timestamp: time.Unix(1, 0)},
+		{Username: "username2", Timestamp: time.Unix(2, 0)},
+		{Username: "username3", Timestamp: time.Unix(3, 0)},
+	}
+	events := []activity.Event{
+		{Category: activity.CategoryLike, Username: "Username1", Timestamp: time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)},
+		{Category: activity.CategorySearch, Username: "username2", Timestamp: time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	tests := []struct {
+		name   string
+		cutoff time.Time
+		want   []string
+	}{
+		{
+			name:   "excludes accounts with any interaction",
+			cutoff: time.Time{},
+			want:   []string{"username3"},
+		},
+		{
+			name:   "excludes accounts with interactions since cutoff",
+			cutoff: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+			want:   []string{"username2", "username3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active := sdk.NewUsernameSet(nil)
+			for _, event := range events {
+				markActive(active, event, tt.cutoff)
+			}
+			il := newInactiveList(following, active)
+			got := make([]string, 0)
+			for _, u := range il.users {
+				got = append(got, u.Username)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package followdata
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	DefaultWeights  = "comments=3,follower=1,following=1,likes=1,messages=2,stories=2"
+	tierCasual      = "casual"
+	tierClose       = "close"
+	tierClosest     = "closest"
+	tierNone        = "no interaction"
+	weightComments  = "comments"
+	weightFollower  = "follower"
+	weightFollowing = "following"
+	weightLikes     = "likes"
+	weightMessages  = "messages"
+	weightStories   = "stories"
+)
+
+func (h *handler) Relationships(ctx context.Context, opts *instagram.Options, weights string) (*string, error) {
+	w, err := parseWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+	relationships, err := h.loader().Relationships(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return render(newRelationshipList(relationships, w), opts)
+}
+
+type weights struct {
+	comments  float64
+	follower  float64
+	following float64
+	likes     float64
+	messages  float64
+	stories   float64
+}
+
+func parseWeights(value string) (*weights, error) {
+	w := &weights{}
+	for _, overrides := range []string{DefaultWeights, value} {
+		if err := w.apply(overrides); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (w *weights) apply(value string) error {
+	targets := map[string]*float64{
+		weightComments:  &w.comments,
+		weightFollower:  &w.follower,
+		weightFollowing: &w.following,
+		weightLikes:     &w.likes,
+		weightMessages:  &w.messages,
+		weightStories:   &w.stories,
+	}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return fmt.Errorf("invalid weight: %s", pair)
+		}
+		target, ok := targets[key]
+		if !ok {
+			return fmt.Errorf("invalid weight: %s", pair)
+		}
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid weight: %s", pair)
+		}
+		*target = parsed
+	}
+	return nil
+}
+
+type engagement struct {
+	Follower        bool                 `json:"follower" yaml:"follower"`
+	Following       bool                 `json:"following" yaml:"following"`
+	Likes           int                  `json:"likes" yaml:"likes"`
+	Comments        int                  `json:"comments" yaml:"comments"`
+	Messages        int                  `json:"messages" yaml:"messages"`
+	Stories         int                  `json:"stories" yaml:"stories"`
+	Score           float64              `json:"score" yaml:"score"`
+	Tier            string               `json:"tier" yaml:"tier"`
+	LastInteraction *instagram.Timestamp `json:"lastInteraction,omitempty" yaml:"lastInteraction,omitempty"`
+}
+
+func engagementHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderFollower,
+		instagram.TableHeaderFollowing,
+		instagram.TableHeaderLikes,
+		instagram.TableHeaderComments,
+		instagram.TableHeaderMessages,
+		instagram.TableHeaderStories,
+		instagram.TableHeaderScore,
+		instagram.TableHeaderTier,
+		instagram.TableHeaderLastInteraction,
+	}
+}
+
+func (e *engagement) row() table.Row {
+	var lastInteraction any = ""
+	if e.LastInteraction != nil {
+		lastInteraction = e.LastInteraction
+	}
+	return table.Row{
+		e.Follower,
+		e.Following,
+		e.Likes,
+		e.Comments,
+		e.Messages,
+		e.Stories,
+		e.Score,
+		e.Tier,
+		lastInteraction,
+	}
+}
+
+func (e *engagement) less(other *engagement) bool {
+	if e == nil || other == nil {
+		return e == nil && other != nil
+	}
+	if e.Score != other.Score {
+		return e.Score < other.Score
+	}
+	return e.interactions() < other.interactions()
+}
+
+func (e *engagement) interactions() int {
+	return e.Likes + e.Comments + e.Messages + e.Stories
+}
+
+func (e *engagement) computeScore(w *weights) {
+	score := float64(e.Likes)*w.likes +
+		float64(e.Comments)*w.comments +
+		float64(e.Messages)*w.messages +
+		float64(e.Stories)*w.stories
+	if e.Follower {
+		score += w.follower
+	}
+	if e.Following {
+		score += w.following
+	}
+	e.Score = math.Round(score*100) / 100
+}
+
+func tier(e *engagement, maxScore float64) string {
+	if e.interactions() == 0 {
+		return tierNone
+	}
+	ratio := 0.0
+	if maxScore > 0 {
+		ratio = e.Score / maxScore
+	}
+	switch {
+	case ratio >= 0.5:
+		return tierClosest
+	case ratio >= 0.2:
+		return tierClose
+	default:
+		return tierCasual
+	}
+}
+
+func newRelationshipList(relationships []sdk.Relationship, w *weights) *userList {
+	rl := &userList{
+		users:          make([]user, 0, len(relationships)),
+		showEngagement: true,
+	}
+	maxScore := 0.0
+	for _, r := range relationships {
+		e := &engagement{
+			Follower:  r.Follower,
+			Following: r.Following,
+			Likes:     r.Likes,
+			Comments:  r.Comments,
+			Messages:  r.Messages,
+			Stories:   r.Stories,
+		}
+		timestamp := instagram.NewTimestamp(0)
+		if !r.LastInteraction.IsZero() {
+			e.LastInteraction = &instagram.Timestamp{Time: r.LastInteraction}
+			timestamp = &instagram.Timestamp{Time: r.LastInteraction}
+		}
+		e.computeScore(w)
+		if e.interactions() > 0 {
+			maxScore = math.Max(maxScore, e.Score)
+		}
+		rl.Append(user{
+			ProfileUrl: r.ProfileUrl,
+			Username:   r.Username,
+			Timestamp:  timestamp,
+			Engagement: e,
+		})
+	}
+	for i := range rl.users {
+		rl.users[i].Engagement.Tier = tier(rl.users[i].Engagement, maxScore)
+	}
+	return rl
+}
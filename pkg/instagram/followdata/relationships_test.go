@@ -0,0 +1,258 @@
+package followdata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	relationshipsFollowersJson = `[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1}]},{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":2}]}]`
+	relationshipsFollowingJson = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":3}]}]}`
+	relationshipsLikesJson     = `{"likes_media_likes":[{"title":"username1","string_list_data":[{"timestamp":1696161600}]},{"title":"username3","string_list_data":[{"timestamp":1696165200}]}]}`
+)
+
+func expectRelationshipFiles(f *filesystem.MockFs) {
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+	f.On("Open", "followers").Return(io.NopCloser(strings.NewReader(relationshipsFollowersJson)), nil)
+	f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(relationshipsFollowingJson)), nil)
+	f.On("ReadFile", instagram.PathLikedPosts).Return([]byte(relationshipsLikesJson), nil)
+	f.On("FindFiles", instagram.PathPostComments).Return([]string{}, nil)
+	f.On("FindFiles", instagram.PathMessages).Return([]string{}, nil)
+	f.On("FindFiles", instagram.PathStoryStickers).Return([]string{}, nil)
+}
+
+func Test_handler_Relationships(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		weights      string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name:    "succeeds to output relationships",
+			weights: DefaultWeights,
+			expectations: func(f *fields) {
+				expectRelationshipFiles(f.fileSystem)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 4)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "succeeds to output relationships when likes are missing",
+			weights: DefaultWeights,
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return(nil, fs.ErrNotExist)
+				expectRelationshipFiles(f.fileSystem)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 4)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "fails to parse weights",
+			weights:      "likes=abc",
+			expectations: nil,
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 0)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails to load followers",
+			weights: DefaultWeights,
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails to load following",
+			weights: DefaultWeights,
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(relationshipsFollowersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails to load activity",
+			weights: DefaultWeights,
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(relationshipsFollowersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(relationshipsFollowingJson)), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Relationships(context.Background(), instagram.NewEmptyOptions(), tt.weights); (err != nil) != tt.wantErr {
+				t.Errorf("Relationships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_parseWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *weights
+		wantErr bool
+	}{
+		{
+			name:  "succeeds to parse default weights",
+			value: DefaultWeights,
+			want: &weights{
+				comments:  3,
+				follower:  1,
+				following: 1,
+				likes:     1,
+				messages:  2,
+				stories:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "succeeds to override default weights",
+			value: "likes=0.5, messages=4",
+			want: &weights{
+				comments:  3,
+				follower:  1,
+				following: 1,
+				likes:     0.5,
+				messages:  4,
+				stories:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "succeeds to parse empty weights",
+			value: "",
+			want: &weights{
+				comments:  3,
+				follower:  1,
+				following: 1,
+				likes:     1,
+				messages:  2,
+				stories:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "succeeds to parse weights with trailing comma",
+			value: "likes=2,",
+			want: &weights{
+				comments:  3,
+				follower:  1,
+				following: 1,
+				likes:     2,
+				messages:  2,
+				stories:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "fails to parse weight without value",
+			value:   "likes",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fails to parse unknown weight",
+			value:   "shares=1",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fails to parse negative weight",
+			value:   "likes=-1",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWeights(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseWeights() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newRelationshipList(t *testing.T) {
+	relationships := []sdk.Relationship{
+		{Account: sdk.Account{Username: "username1"}, Follower: true, Following: true, Messages: 2, Comments: 1},
+		{Account: sdk.Account{Username: "username2"}, Follower: true},
+		{Account: sdk.Account{Username: "username3"}, Likes: 1},
+	}
+	w, err := parseWeights(DefaultWeights)
+	assert.NoError(t, err)
+	rl := newRelationshipList(relationships, w)
+	rl.Sort(instagram.FieldScore, instagram.OrderDesc)
+	got := make(map[string]string)
+	usernames := make([]string, 0)
+	for _, u := range rl.users {
+		got[u.Username] = fmt.Sprintf("%v/%s", u.Engagement.Score, u.Engagement.Tier)
+		usernames = append(usernames, u.Username)
+	}
+	assert.Equal(t, []string{"username1", "username3", "username2"}, usernames)
+	assert.Equal(t, map[string]string{
+		"username1": "9/closest",
+		"username2": "1/no interaction",
+		"username3": "1/casual",
+	}, got)
+	output, err := rl.output(&instagram.Options{Output: instagram.OutputJson})
+	assert.NoError(t, err)
+	assert.Contains(t, *output, `"tier": "closest"`)
+	assert.NotContains(t, *output, `"timestamp"`)
+}
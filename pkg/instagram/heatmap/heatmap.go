@@ -0,0 +1,168 @@
+package heatmap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/timeline"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	CategoryDms     = "dms"
+	CategoryFollows = "follows"
+	CategoryLikes   = "likes"
+	CategoryPosts   = "posts"
+	hoursPerDay     = 24
+)
+
+var categoryTypes = map[string][]string{
+	CategoryDms:     {activity.CategoryMessage},
+	CategoryFollows: {activity.CategoryFollower, activity.CategoryFollowing},
+	CategoryLikes:   {activity.CategoryLike},
+	CategoryPosts:   {activity.CategoryPost},
+}
+
+type Interface interface {
+	Heatmap(opts *instagram.Options, categories string, timezone string) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Heatmap(opts *instagram.Options, categories string, timezone string) (*string, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	types, err := ParseCategories(categories)
+	if err != nil {
+		return nil, err
+	}
+	events, err := timeline.LoadEvents(h.fileSystem, types)
+	if err != nil {
+		return nil, err
+	}
+	dl := newDayList(events, location)
+	dl.Sort(opts.SortBy, opts.Order)
+	dl.Paginate(opts)
+	return instagram.Output(dl, opts)
+}
+
+func Categories() []string {
+	return []string{
+		CategoryDms,
+		CategoryFollows,
+		CategoryLikes,
+		CategoryPosts,
+	}
+}
+
+func ParseCategories(value string) ([]string, error) {
+	if value == "" {
+		return timeline.Types(), nil
+	}
+	types := make([]string, 0)
+	for _, category := range strings.Split(value, ",") {
+		category = strings.ToLower(strings.TrimSpace(category))
+		mapped, ok := categoryTypes[category]
+		if !ok {
+			return nil, fmt.Errorf("invalid category: %s", category)
+		}
+		for _, t := range mapped {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	return types, nil
+}
+
+type day struct {
+	Day     string           `json:"day" yaml:"day"`
+	Hours   [hoursPerDay]int `json:"hours" yaml:"hours,flow"`
+	Total   int              `json:"total" yaml:"total"`
+	weekday time.Weekday
+}
+
+type dayList struct {
+	days []day
+}
+
+func newDayList(events []activity.Event, location *time.Location) *dayList {
+	dl := &dayList{
+		days: make([]day, 0, 7),
+	}
+	for i := 0; i < 7; i++ {
+		weekday := time.Weekday((i + 1) % 7)
+		dl.days = append(dl.days, day{
+			Day:     weekday.String(),
+			weekday: weekday,
+		})
+	}
+	for _, event := range events {
+		local := event.Timestamp.In(location)
+		index := (int(local.Weekday()) + 6) % 7
+		dl.days[index].Hours[local.Hour()]++
+		dl.days[index].Total++
+	}
+	return dl
+}
+
+func (dl *dayList) Data() any {
+	return dl.days
+}
+
+func (dl *dayList) TableHeader() table.Row {
+	header := table.Row{instagram.TableHeaderDay}
+	for hour := 0; hour < hoursPerDay; hour++ {
+		header = append(header, fmt.Sprintf("%02d", hour))
+	}
+	return append(header, instagram.TableHeaderTotal)
+}
+
+func (dl *dayList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range dl.days {
+		current := dl.days[i]
+		row := table.Row{current.Day}
+		for hour := range current.Hours {
+			row = append(row, current.Hours[hour])
+		}
+		rows = append(rows, append(row, current.Total))
+	}
+	return rows
+}
+
+func (dl *dayList) Sort(field string, order string) {
+	sort.SliceStable(dl.days, func(a, b int) bool {
+		dayOne := dl.days[a]
+		dayTwo := dl.days[b]
+		switch field {
+		case instagram.FieldCount:
+			return dayOne.Total < dayTwo.Total
+		default:
+			return (dayOne.weekday+6)%7 < (dayTwo.weekday+6)%7
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(dl.days)
+	}
+}
+
+func (dl *dayList) Paginate(opts *instagram.Options) {
+	dl.days = instagram.Paginate(dl.days, opts)
+}
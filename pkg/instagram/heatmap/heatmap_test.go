@@ -0,0 +1,179 @@
+package heatmap
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/timeline"
+	"github.com/stretchr/testify/assert"
+)
+
+const likedPostsJson = `{"likes_media_likes":[{"title":"username1","string_list_data":[{"timestamp":1696161600}]},{"title":"username2","string_list_data":[{"timestamp":1696334400}]}]}`
+
+func Test_handler_Heatmap(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	type args struct {
+		categories string
+		timezone   string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output heatmap",
+			args: args{
+				categories: CategoryLikes,
+				timezone:   "Europe/Dublin",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likedPostsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to load timezone",
+			args: args{
+				categories: CategoryLikes,
+				timezone:   "Invalid/Zone",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse categories",
+			args: args{
+				categories: "invalid",
+				timezone:   "UTC",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load events",
+			args: args{
+				categories: CategoryLikes,
+				timezone:   "UTC",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Heatmap(instagram.NewEmptyOptions(), tt.args.categories, tt.args.timezone); (err != nil) != tt.wantErr {
+				t.Errorf("Heatmap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func TestParseCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "succeeds to parse empty value as all events",
+			value: "",
+			want:  timeline.Types(),
+		},
+		{
+			name:  "succeeds to parse likes",
+			value: "likes",
+			want:  []string{activity.CategoryLike},
+		},
+		{
+			name:  "succeeds to parse dms case-insensitively",
+			value: "DMs",
+			want:  []string{activity.CategoryMessage},
+		},
+		{
+			name:  "succeeds to parse posts",
+			value: "posts",
+			want:  []string{activity.CategoryPost},
+		},
+		{
+			name:  "succeeds to parse follows as followers and following",
+			value: "follows",
+			want:  []string{activity.CategoryFollower, activity.CategoryFollowing},
+		},
+		{
+			name:  "succeeds to parse multiple categories",
+			value: "likes, follows,likes",
+			want:  []string{activity.CategoryLike, activity.CategoryFollower, activity.CategoryFollowing},
+		},
+		{
+			name:    "fails to parse timeline event type",
+			value:   "like",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCategories(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCategories() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newDayList(t *testing.T) {
+	events := []activity.Event{
+		{Category: activity.CategoryLike, Timestamp: time.Date(2023, time.October, 1, 23, 30, 0, 0, time.UTC)},
+		{Category: activity.CategoryLike, Timestamp: time.Date(2023, time.October, 2, 9, 0, 0, 0, time.UTC)},
+		{Category: activity.CategoryPost, Timestamp: time.Date(2023, time.October, 2, 9, 15, 0, 0, time.UTC)},
+	}
+	location, err := time.LoadLocation("Europe/Sofia")
+	assert.NoError(t, err)
+	dl := newDayList(events, location)
+	assert.Equal(t, 7, len(dl.days))
+	assert.Equal(t, "Monday", dl.days[0].Day)
+	assert.Equal(t, 1, dl.days[0].Hours[2])
+	assert.Equal(t, 2, dl.days[0].Hours[12])
+	assert.Equal(t, 3, dl.days[0].Total)
+	assert.Equal(t, "Sunday", dl.days[6].Day)
+	assert.Equal(t, 0, dl.days[6].Total)
+	dl.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, "Monday", dl.days[0].Day)
+	dl.Sort(instagram.FieldTimestamp, instagram.OrderAsc)
+	assert.Equal(t, "Monday", dl.days[0].Day)
+	assert.Equal(t, "Sunday", dl.days[6].Day)
+	assert.Equal(t, 26, len(dl.TableHeader()))
+	assert.Equal(t, 26, len(dl.TableRows()[0]))
+	output, err := instagram.Output(dl, &instagram.Options{Output: instagram.OutputYaml})
+	assert.NoError(t, err)
+	assert.Contains(t, *output, "hours: [0, 0, 1, 0,")
+}
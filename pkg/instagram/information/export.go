@@ -0,0 +1,160 @@
+package information
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/timeline"
+	_ "modernc.org/sqlite"
+)
+
+const driverSqlite = "sqlite"
+
+var exportCategories = []string{
+	activity.CategoryComment,
+	activity.CategoryFollower,
+	activity.CategoryFollowing,
+	activity.CategoryLike,
+	activity.CategoryLogin,
+	activity.CategoryMessage,
+	activity.CategoryPost,
+}
+
+var exportSchema = []string{
+	`PRAGMA user_version = 1`,
+	`CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT NOT NULL UNIQUE COLLATE NOCASE)`,
+	`CREATE TABLE follows (user_id INTEGER NOT NULL REFERENCES users (id), direction TEXT NOT NULL CHECK (direction IN ('follower', 'following')), timestamp TEXT NOT NULL, PRIMARY KEY (user_id, direction))`,
+	`CREATE TABLE likes (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id), timestamp TEXT NOT NULL)`,
+	`CREATE TABLE comments (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id), text TEXT NOT NULL, timestamp TEXT NOT NULL)`,
+	`CREATE TABLE messages (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id), sender TEXT NOT NULL, text TEXT NOT NULL, timestamp TEXT NOT NULL)`,
+	`CREATE TABLE posts (id INTEGER PRIMARY KEY, caption TEXT NOT NULL, timestamp TEXT NOT NULL)`,
+	`CREATE TABLE logins (id INTEGER PRIMARY KEY, ip_address TEXT NOT NULL, timestamp TEXT NOT NULL)`,
+	`CREATE INDEX follows_direction ON follows (direction)`,
+	`CREATE INDEX likes_user_id ON likes (user_id)`,
+	`CREATE INDEX likes_timestamp ON likes (timestamp)`,
+	`CREATE INDEX comments_user_id ON comments (user_id)`,
+	`CREATE INDEX comments_timestamp ON comments (timestamp)`,
+	`CREATE INDEX messages_user_id ON messages (user_id)`,
+	`CREATE INDEX messages_timestamp ON messages (timestamp)`,
+	`CREATE INDEX posts_timestamp ON posts (timestamp)`,
+	`CREATE INDEX logins_ip_address ON logins (ip_address)`,
+	`CREATE INDEX logins_timestamp ON logins (timestamp)`,
+}
+
+func (h *handler) Export(sqlitePath string) error {
+	if sqlitePath == "" {
+		return fmt.Errorf("must provide an export destination")
+	}
+	if info, err := os.Stat(sqlitePath); err == nil && info.IsDir() {
+		return fmt.Errorf("export destination is a directory: %s", sqlitePath)
+	}
+	events, err := timeline.LoadEvents(h.fileSystem, exportCategories)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(sqlitePath), filepath.Base(sqlitePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+	if err = temp.Close(); err != nil {
+		return err
+	}
+	if err = exportDatabase(tempPath, events); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, sqlitePath)
+}
+
+func exportDatabase(sqlitePath string, events []activity.Event) error {
+	db, err := sql.Open(driverSqlite, sqlitePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return writeDatabase(db, events)
+}
+
+func writeDatabase(db *sql.DB, events []activity.Event) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, statement := range exportSchema {
+		if _, err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	users, err := insertUsers(tx, events)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].Timestamp.Before(events[b].Timestamp)
+	})
+	for _, e := range events {
+		if err = insertEvent(tx, users, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func insertUsers(tx *sql.Tx, events []activity.Event) (map[string]int64, error) {
+	usernames := make([]string, 0)
+	for _, e := range events {
+		if e.Username != "" && e.Category != activity.CategoryPost && e.Category != activity.CategoryLogin {
+			usernames = append(usernames, strings.ToLower(e.Username))
+		}
+	}
+	slices.Sort(usernames)
+	usernames = slices.Compact(usernames)
+	users := make(map[string]int64, len(usernames))
+	for i, username := range usernames {
+		id := int64(i + 1)
+		if _, err := tx.Exec(`INSERT INTO users (id, username) VALUES (?, ?)`, id, username); err != nil {
+			return nil, err
+		}
+		users[username] = id
+	}
+	return users, nil
+}
+
+func insertEvent(tx *sql.Tx, users map[string]int64, e activity.Event) error {
+	var userID sql.NullInt64
+	if id, ok := users[strings.ToLower(e.Username)]; ok {
+		userID = sql.NullInt64{Int64: id, Valid: true}
+	}
+	timestamp := e.Timestamp.UTC().Format(time.RFC3339)
+	var err error
+	switch e.Category {
+	case activity.CategoryFollower, activity.CategoryFollowing:
+		if !userID.Valid {
+			return nil
+		}
+		_, err = tx.Exec(`INSERT OR IGNORE INTO follows (user_id, direction, timestamp) VALUES (?, ?, ?)`, userID, e.Category, timestamp)
+	case activity.CategoryLike:
+		if !userID.Valid {
+			return nil
+		}
+		_, err = tx.Exec(`INSERT INTO likes (user_id, timestamp) VALUES (?, ?)`, userID, timestamp)
+	case activity.CategoryComment:
+		_, err = tx.Exec(`INSERT INTO comments (user_id, text, timestamp) VALUES (?, ?, ?)`, userID, e.Text, timestamp)
+	case activity.CategoryMessage:
+		_, err = tx.Exec(`INSERT INTO messages (user_id, sender, text, timestamp) VALUES (?, ?, ?, ?)`, userID, e.Actor, e.Text, timestamp)
+	case activity.CategoryPost:
+		_, err = tx.Exec(`INSERT INTO posts (caption, timestamp) VALUES (?, ?)`, e.Text, timestamp)
+	case activity.CategoryLogin:
+		_, err = tx.Exec(`INSERT INTO logins (ip_address, timestamp) VALUES (?, ?)`, e.Text, timestamp)
+	}
+	return err
+}
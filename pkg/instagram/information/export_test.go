@@ -0,0 +1,172 @@
+package information
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	followersJson  = `[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1696248000}]}]`
+	followingJson  = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1696075200}]}]}`
+	likedPostsJson = `{"likes_media_likes":[{"title":"username2","string_list_data":[{"timestamp":1696161600}]}]}`
+)
+
+func Test_handler_Export(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		sqlitePath   string
+		expectations func(f *fields, sqlitePath string)
+		assertions   func(t *testing.T, f *fields, sqlitePath string)
+		wantErr      bool
+	}{
+		{
+			name:       "succeeds to export database",
+			sqlitePath: "out.db",
+			expectations: func(f *fields, sqlitePath string) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likedPostsJson), nil)
+				f.fileSystem.On("ReadFile", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("Open", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{}, nil)
+				assert.NoError(t, os.WriteFile(sqlitePath, []byte("previous export"), 0644))
+			},
+			assertions: func(t *testing.T, f *fields, sqlitePath string) {
+				f.fileSystem.AssertNumberOfCalls(t, "RemoveDirectory", 0)
+				temps, err := filepath.Glob(sqlitePath + ".*.tmp")
+				assert.NoError(t, err)
+				assert.Empty(t, temps)
+				db, err := sql.Open(driverSqlite, sqlitePath)
+				assert.NoError(t, err)
+				defer db.Close()
+				assert.Equal(t, 2, countRows(t, db, "users"))
+				assert.Equal(t, 2, countRows(t, db, "follows"))
+				assert.Equal(t, 1, countRows(t, db, "likes"))
+			},
+			wantErr: false,
+		},
+		{
+			name:       "fails to export without destination",
+			sqlitePath: "",
+			wantErr:    true,
+		},
+		{
+			name:       "fails to load events",
+			sqlitePath: "out.db",
+			expectations: func(f *fields, sqlitePath string) {
+				f.fileSystem.On("FindFiles", instagram.PathPostComments).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields, sqlitePath string) {
+				f.fileSystem.AssertNumberOfCalls(t, "RemoveDirectory", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "fails to export into directory",
+			sqlitePath: "out",
+			expectations: func(f *fields, sqlitePath string) {
+				assert.NoError(t, os.MkdirAll(filepath.Join(sqlitePath, instagram.PathData), 0755))
+			},
+			assertions: func(t *testing.T, f *fields, sqlitePath string) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 0)
+				assert.DirExists(t, filepath.Join(sqlitePath, instagram.PathData))
+			},
+			wantErr: true,
+		},
+		{
+			name:       "fails to create database in missing directory",
+			sqlitePath: filepath.Join("missing", "out.db"),
+			expectations: func(f *fields, sqlitePath string) {
+				f.fileSystem.On("ReadFile", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("Open", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			assertions: func(t *testing.T, f *fields, sqlitePath string) {
+				assert.NoFileExists(t, sqlitePath)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			sqlitePath := tt.sqlitePath
+			if sqlitePath != "" {
+				sqlitePath = filepath.Join(t.TempDir(), sqlitePath)
+			}
+			if tt.expectations != nil {
+				tt.expectations(f, sqlitePath)
+			}
+			if err := h.Export(sqlitePath); (err != nil) != tt.wantErr {
+				t.Errorf("Export() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f, sqlitePath)
+			}
+		})
+	}
+}
+
+func Test_writeDatabase(t *testing.T) {
+	db, err := sql.Open(driverSqlite, filepath.Join(t.TempDir(), "out.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+	events := []activity.Event{
+		{Category: activity.CategoryMessage, Actor: "User One", Username: "username1", Text: "hello", Timestamp: time.Unix(1696161600, 0)},
+		{Category: activity.CategoryComment, Username: "username2", Text: "nice", Timestamp: time.Unix(1696075200, 0)},
+		{Category: activity.CategoryPost, Text: "caption", Timestamp: time.Unix(1696248000, 0)},
+		{Category: activity.CategoryLogin, Text: "127.0.0.1", Timestamp: time.Unix(1696334400, 0)},
+		{Category: activity.CategoryFollower, Username: "username1", Timestamp: time.Unix(1696075200, 0)},
+		{Category: activity.CategoryFollower, Username: "Username1", Timestamp: time.Unix(1696075200, 0)},
+	}
+	assert.NoError(t, writeDatabase(db, events))
+	assert.Equal(t, 2, countRows(t, db, "users"))
+	assert.Equal(t, 1, countRows(t, db, "follows"))
+	assert.Equal(t, 1, countRows(t, db, "comments"))
+	assert.Equal(t, 1, countRows(t, db, "messages"))
+	assert.Equal(t, 1, countRows(t, db, "posts"))
+	assert.Equal(t, 1, countRows(t, db, "logins"))
+	var username, sender, timestamp string
+	err = db.QueryRow(`SELECT users.username, messages.sender, messages.timestamp FROM messages JOIN users ON users.id = messages.user_id`).Scan(&username, &sender, &timestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, "username1", username)
+	assert.Equal(t, "User One", sender)
+	assert.Equal(t, "2023-10-01T12:00:00Z", timestamp)
+	var id int
+	assert.NoError(t, db.QueryRow(`SELECT id FROM users WHERE username = ?`, "USERNAME1").Scan(&id))
+	assert.Equal(t, 1, id)
+	var version int
+	assert.NoError(t, db.QueryRow(`PRAGMA user_version`).Scan(&version))
+	assert.Equal(t, 1, version)
+	assert.Error(t, writeDatabase(db, events))
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
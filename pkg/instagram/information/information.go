@@ -12,6 +12,7 @@ import (
 
 type Interface interface {
 	Cleanup() error
+	Export(sqlitePath string) error
 	Load(source string) error
 }
 
@@ -19,9 +20,9 @@ type handler struct {
 	fileSystem filesystem.Fs
 }
 
-func NewHandler() Interface {
+func NewHandler(fileSystem filesystem.Fs) Interface {
 	return &handler{
-		fileSystem: filesystem.NewFs(),
+		fileSystem: fileSystem,
 	}
 }
 
@@ -50,6 +51,9 @@ func (h *handler) Load(source string) error {
 	if err != nil {
 		return err
 	}
+	if err = h.fileSystem.CreateDirectory(instagram.PathData, 0755); err != nil {
+		return err
+	}
 	file, err := h.fileSystem.CreateFile(instagram.PathDataArchive)
 	if err != nil {
 		return err
@@ -102,6 +102,7 @@ func Test_informationHandler_Load(t *testing.T) {
 			httpStatusCode: http.StatusOK,
 			expectations: func(f *fields) {
 				dummyFile := &os.File{}
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(nil)
 				f.fileSystem.On("CreateFile", instagram.PathDataArchive).Return(dummyFile, nil)
 				f.fileSystem.On("CopyToFile", dummyFile, http.NoBody).Return(int64(0), nil)
 				f.fileSystem.On("Unzip", instagram.PathDataArchive, instagram.PathData).Return(nil)
@@ -127,12 +128,26 @@ func Test_informationHandler_Load(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fails to create data directory",
+			args: args{
+				source: "",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(fmt.Errorf("fails to create directory"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "CreateFile", 0)
+			},
+			wantErr: true,
+		},
 		{
 			name: "fails to create file",
 			args: args{
 				source: "",
 			},
 			expectations: func(f *fields) {
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(nil)
 				f.fileSystem.On("CreateFile", instagram.PathDataArchive).Return(nil, fmt.Errorf("fails to create file"))
 			},
 			assertions: func(t *testing.T, f *fields) {
@@ -146,6 +161,7 @@ func Test_informationHandler_Load(t *testing.T) {
 				source: "",
 			},
 			expectations: func(f *fields) {
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(nil)
 				f.fileSystem.On("CreateFile", instagram.PathDataArchive).Return(&os.File{}, nil)
 			},
 			assertions: func(t *testing.T, f *fields) {
@@ -160,6 +176,7 @@ func Test_informationHandler_Load(t *testing.T) {
 			},
 			httpStatusCode: http.StatusNotFound,
 			expectations: func(f *fields) {
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(nil)
 				f.fileSystem.On("CreateFile", instagram.PathDataArchive).Return(&os.File{}, nil)
 
 			},
@@ -176,6 +193,7 @@ func Test_informationHandler_Load(t *testing.T) {
 			httpStatusCode: http.StatusOK,
 			expectations: func(f *fields) {
 				dummyFile := &os.File{}
+				f.fileSystem.On("CreateDirectory", instagram.PathData, os.FileMode(0755)).Return(nil)
 				f.fileSystem.On("CreateFile", instagram.PathDataArchive).Return(dummyFile, nil)
 				f.fileSystem.On("CopyToFile", dummyFile, http.NoBody).Return(int64(0), fmt.Errorf("fails to copy http response body to file"))
 			},
@@ -0,0 +1,282 @@
+package offsite
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	keyCityName  = "City Name"
+	typeInterest = "interest"
+	typePrimary  = "primary"
+)
+
+type Interface interface {
+	Apps(opts *instagram.Options) (*string, error)
+	Locations(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Apps(opts *instagram.Options) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathOffMetaActivity)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathOffMetaActivity, err)
+	}
+	al, err := parseApps(data)
+	if err != nil {
+		return nil, err
+	}
+	al.Sort(opts.SortBy, opts.Order)
+	al.Paginate(opts)
+	return instagram.Output(al, opts)
+}
+
+func (h *handler) Locations(opts *instagram.Options) (*string, error) {
+	ll := &locationList{
+		locations: make([]location, 0),
+	}
+	data, err := h.fileSystem.ReadFile(instagram.PathLocationsOfInterest)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathLocationsOfInterest, err)
+	}
+	interests, err := parseLocationsOfInterest(data)
+	if err != nil {
+		return nil, err
+	}
+	ll.locations = append(ll.locations, interests...)
+	data, err = h.fileSystem.ReadFile(instagram.PathAccountLocation)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		primary, err := parseAccountLocation(data)
+		if err != nil {
+			return nil, err
+		}
+		ll.locations = append(ll.locations, primary...)
+	}
+	ll.Sort(opts.SortBy, opts.Order)
+	ll.Paginate(opts)
+	return instagram.Output(ll, opts)
+}
+
+type appOriginal struct {
+	Name   string          `json:"name"`
+	Events []eventOriginal `json:"events"`
+}
+
+type eventOriginal struct {
+	Type      string `json:"type"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type labelValueOriginal struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+	Vec   []struct {
+		Value string `json:"value"`
+	} `json:"vec"`
+}
+
+func parseApps(data []byte) (*appList, error) {
+	jsonData := make(map[string][]appOriginal)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	al := &appList{
+		apps: make([]app, 0),
+	}
+	for _, apps := range jsonData {
+		for _, original := range apps {
+			if len(original.Events) == 0 {
+				continue
+			}
+			a := app{
+				Name:       original.Name,
+				Events:     len(original.Events),
+				EventTypes: make([]string, 0),
+			}
+			first, last := original.Events[0].Timestamp, original.Events[0].Timestamp
+			for _, event := range original.Events {
+				if event.Type != "" && !slices.Contains(a.EventTypes, event.Type) {
+					a.EventTypes = append(a.EventTypes, event.Type)
+				}
+				first = min(first, event.Timestamp)
+				last = max(last, event.Timestamp)
+			}
+			sort.Strings(a.EventTypes)
+			a.FirstEvent = instagram.NewTimestamp(first)
+			a.LastEvent = instagram.NewTimestamp(last)
+			al.apps = append(al.apps, a)
+		}
+	}
+	return al, nil
+}
+
+func parseLocationsOfInterest(data []byte) ([]location, error) {
+	jsonData := make(map[string][]labelValueOriginal)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	locations := make([]location, 0)
+	for _, labelValues := range jsonData {
+		for _, lv := range labelValues {
+			if lv.Value != "" {
+				locations = append(locations, location{
+					Location: lv.Value,
+					Type:     typeInterest,
+				})
+			}
+			for _, v := range lv.Vec {
+				locations = append(locations, location{
+					Location: v.Value,
+					Type:     typeInterest,
+				})
+			}
+		}
+	}
+	return locations, nil
+}
+
+func parseAccountLocation(data []byte) ([]location, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	locations := make([]location, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			if city := entry.StringMapData[keyCityName].Value; city != "" {
+				locations = append(locations, location{
+					Location: city,
+					Type:     typePrimary,
+				})
+			}
+		}
+	}
+	return locations, nil
+}
+
+type app struct {
+	Name       string               `json:"name" yaml:"name"`
+	Events     int                  `json:"events" yaml:"events"`
+	EventTypes []string             `json:"eventTypes" yaml:"eventTypes"`
+	FirstEvent *instagram.Timestamp `json:"firstEvent" yaml:"firstEvent"`
+	LastEvent  *instagram.Timestamp `json:"lastEvent" yaml:"lastEvent"`
+}
+
+type appList struct {
+	apps []app
+}
+
+func (al *appList) Data() any {
+	return al.apps
+}
+
+func (al *appList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderName,
+		instagram.TableHeaderEvents,
+		instagram.TableHeaderEventTypes,
+		instagram.TableHeaderFirstEvent,
+		instagram.TableHeaderLastEvent,
+	}
+}
+
+func (al *appList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range al.apps {
+		current := al.apps[i]
+		rows = append(rows, table.Row{
+			current.Name,
+			current.Events,
+			strings.Join(current.EventTypes, ", "),
+			current.FirstEvent,
+			current.LastEvent,
+		})
+	}
+	return rows
+}
+
+func (al *appList) Sort(field string, order string) {
+	sort.SliceStable(al.apps, func(a, b int) bool {
+		appOne := al.apps[a]
+		appTwo := al.apps[b]
+		switch field {
+		case instagram.FieldName:
+			return strings.ToLower(appOne.Name) < strings.ToLower(appTwo.Name)
+		case instagram.FieldTimestamp:
+			return appOne.LastEvent.Time.Before(appTwo.LastEvent.Time)
+		default:
+			return appOne.Events < appTwo.Events
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(al.apps)
+	}
+}
+
+func (al *appList) Paginate(opts *instagram.Options) {
+	al.apps = instagram.Paginate(al.apps, opts)
+}
+
+type location struct {
+	Location string `json:"location" yaml:"location"`
+	Type     string `json:"type" yaml:"type"`
+}
+
+type locationList struct {
+	locations []location
+}
+
+func (ll *locationList) Data() any {
+	return ll.locations
+}
+
+func (ll *locationList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderLocation,
+		instagram.TableHeaderType,
+	}
+}
+
+func (ll *locationList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range ll.locations {
+		rows = append(rows, table.Row{
+			ll.locations[i].Location,
+			ll.locations[i].Type,
+		})
+	}
+	return rows
+}
+
+func (ll *locationList) Sort(_ string, order string) {
+	sort.SliceStable(ll.locations, func(a, b int) bool {
+		return ll.locations[a].Location < ll.locations[b].Location
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(ll.locations)
+	}
+}
+
+func (ll *locationList) Paginate(opts *instagram.Options) {
+	ll.locations = instagram.Paginate(ll.locations, opts)
+}
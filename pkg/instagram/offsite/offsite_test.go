@@ -0,0 +1,189 @@
+package offsite
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	offMetaActivityJson     = `{"apps_and_websites_off_meta_activity":[{"name":"app1","events":[{"id":1,"type":"PURCHASE","timestamp":1696248000},{"id":2,"type":"PAGE_VIEW","timestamp":1696161600},{"id":3,"type":"PURCHASE","timestamp":1696334400}]},{"name":"app2","events":[{"id":4,"type":"CUSTOM","timestamp":1696161600}]},{"name":"app3","events":[]}]}`
+	locationsOfInterestJson = `{"label_values":[{"label":"Locations of interest","vec":[{"value":"Dublin"},{"value":"Sofia"}]}]}`
+	accountLocationJson     = `{"inferred_data_primary_location":[{"title":"","string_map_data":{"City Name":{"value":"Dublin"}}}]}`
+)
+
+func Test_handler_Apps(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output apps",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathOffMetaActivity).Return([]byte(offMetaActivityJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathOffMetaActivity).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse apps",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathOffMetaActivity).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Apps(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Apps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Locations(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output locations",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLocationsOfInterest).Return([]byte(locationsOfInterestJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountLocation).Return([]byte(accountLocationJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output locations when optional file is missing",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLocationsOfInterest).Return([]byte(locationsOfInterestJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountLocation).Return(nil, fs.ErrNotExist)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read required file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLocationsOfInterest).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse account location",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLocationsOfInterest).Return([]byte(locationsOfInterestJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountLocation).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Locations(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Locations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_parseApps(t *testing.T) {
+	al, err := parseApps([]byte(offMetaActivityJson))
+	assert.NoError(t, err)
+	al.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, []app{
+		{
+			Name:       "app1",
+			Events:     3,
+			EventTypes: []string{"PAGE_VIEW", "PURCHASE"},
+			FirstEvent: instagram.NewTimestamp(1696161600),
+			LastEvent:  instagram.NewTimestamp(1696334400),
+		},
+		{
+			Name:       "app2",
+			Events:     1,
+			EventTypes: []string{"CUSTOM"},
+			FirstEvent: instagram.NewTimestamp(1696161600),
+			LastEvent:  instagram.NewTimestamp(1696161600),
+		},
+	}, al.apps)
+}
+
+func Test_parseLocations(t *testing.T) {
+	interests, err := parseLocationsOfInterest([]byte(locationsOfInterestJson))
+	assert.NoError(t, err)
+	assert.Equal(t, []location{
+		{Location: "Dublin", Type: typeInterest},
+		{Location: "Sofia", Type: typeInterest},
+	}, interests)
+	primary, err := parseAccountLocation([]byte(accountLocationJson))
+	assert.NoError(t, err)
+	assert.Equal(t, []location{{Location: "Dublin", Type: typePrimary}}, primary)
+}
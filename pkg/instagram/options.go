@@ -2,64 +2,198 @@ package instagram
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 type Options struct {
-	Limit  int
-	Order  string
-	Output string
-	SortBy string
+	Columns    []string
+	Limit      int
+	Offset     int
+	Order      string
+	Output     string
+	Page       int
+	PageSize   int
+	SortBy     string
+	Template   string
+	flagSortBy string
+	pagination *pagination
 }
 
-func NewOptions(flags *pflag.FlagSet) (*Options, error) {
+type pagination struct {
+	start int
+	end   int
+	total int
+}
+
+func AddCommonFlags(cmd *cobra.Command, order string, sortBy string, sortByFields ...string) {
+	cmd.Flags().Int(FlagLimit, Unlimited, `max results to display, omit this flag or set to 0 for unlimited`)
+	cmd.Flags().Int(FlagOffset, 0, `number of results to skip before displaying`)
+	cmd.Flags().Int(FlagPage, 0, `page number to display, requires --page-size`)
+	cmd.Flags().Int(FlagPageSize, 0, `results per page, omit this flag or set to 0 to disable paging`)
+	cmd.Flags().String(FlagOrder, order, `order direction ("asc", "desc")`)
+	cmd.Flags().String(FlagOutput, OutputTable, `output format ("csv", "json", "jsonl", "markdown", "table", "template", "yaml")`)
+	cmd.Flags().StringSlice(FlagColumns, nil, `comma-separated columns to display in the given order, e.g. "username,timestamp"`)
+	cmd.Flags().String(FlagTemplate, "", `go template applied to each result when the output format is "template", e.g. "{{.Username}}"`)
+	cmd.Flags().String(FlagTemplateFile, "", `path to a go template file, alternative to --template`)
+	cmd.Flags().String(FlagSortBy, sortBy, fmt.Sprintf(`sort by field ("%s")`, strings.Join(sortByFields, `", "`)))
+}
+
+func NewOptions(cmd *cobra.Command) (*Options, error) {
+	flags := cmd.Flags()
+	config := ConfigFromContext(cmd.Context())
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
 	limit, err := flags.GetInt(FlagLimit)
 	if err != nil {
 		return nil, err
 	}
+	if value, ok := lookupDefault(flags, config, command, FlagLimit); ok {
+		if limit, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", value)
+		}
+	}
 	order, err := flags.GetString(FlagOrder)
 	if err != nil {
 		return nil, err
 	}
+	if value, ok := lookupDefault(flags, config, command, FlagOrder); ok {
+		order = value
+	}
 	output, err := flags.GetString(FlagOutput)
 	if err != nil {
 		return nil, err
 	}
-	sortBy, err := flags.GetString(FlagSortBy)
+	if value, ok := lookupDefault(flags, config, command, FlagOutput); ok {
+		output = value
+	}
+	flagSortBy, err := flags.GetString(FlagSortBy)
+	if err != nil {
+		return nil, err
+	}
+	sortBy := flagSortBy
+	if value, ok := lookupDefault(flags, config, command, FlagSortBy); ok {
+		sortBy = value
+	}
+	offset, err := flags.GetInt(FlagOffset)
+	if err != nil {
+		return nil, err
+	}
+	page, err := flags.GetInt(FlagPage)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := flags.GetInt(FlagPageSize)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := flags.GetStringSlice(FlagColumns)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := flags.GetString(FlagTemplate)
 	if err != nil {
 		return nil, err
 	}
+	templateFile, err := flags.GetString(FlagTemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	if templateFile != "" {
+		if tmpl != "" {
+			return nil, fmt.Errorf("cannot use --%s and --%s together", FlagTemplate, FlagTemplateFile)
+		}
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			return nil, err
+		}
+		tmpl = string(data)
+	}
 	return &Options{
-		Limit:  limit,
-		Order:  order,
-		Output: output,
-		SortBy: sortBy,
+		Columns:    columns,
+		Limit:      limit,
+		Offset:     offset,
+		Order:      order,
+		Output:     output,
+		Page:       page,
+		PageSize:   pageSize,
+		SortBy:     sortBy,
+		Template:   tmpl,
+		flagSortBy: flagSortBy,
 	}, nil
 }
 
+func lookupDefault(flags *pflag.FlagSet, config *Config, command string, name string) (string, bool) {
+	if flags.Changed(name) {
+		return "", false
+	}
+	if value, ok := lookupEnv(command, name); ok {
+		return value, true
+	}
+	return config.lookup(command, name)
+}
+
 func NewEmptyOptions() *Options {
 	return &Options{
 		Output: OutputNone,
 	}
 }
 
-func (o *Options) Validate() error {
+func (o *Options) Validate(sortByFields ...string) error {
 	if err := validateLimit(o.Limit); err != nil {
 		return err
 	}
+	if err := validatePagination(o.Limit, o.Offset, o.Page, o.PageSize); err != nil {
+		return err
+	}
 	if err := validateOrder(o.Order); err != nil {
 		return err
 	}
 	if err := validateOutput(o.Output); err != nil {
 		return err
 	}
-	if err := validateSortBy(o.SortBy); err != nil {
+	if o.SortBy != o.flagSortBy && o.flagSortBy != "" && !slices.Contains(sortByFields, o.SortBy) {
+		o.SortBy = o.flagSortBy
+	}
+	if err := validateSortBy(o.SortBy, sortByFields); err != nil {
+		return err
+	}
+	if err := validateColumns(o.Columns); err != nil {
+		return err
+	}
+	if err := validateTemplate(o.Output, o.Template); err != nil {
 		return err
 	}
 	return nil
 }
 
+func validateColumns(values []string) error {
+	for _, value := range values {
+		if columnKey(value) == "" {
+			return fmt.Errorf("invalid column: %q", value)
+		}
+	}
+	return nil
+}
+
+func validateTemplate(output string, value string) error {
+	if output != OutputTemplate {
+		if value != "" {
+			return fmt.Errorf("template requires output format: %s", OutputTemplate)
+		}
+		return nil
+	}
+	if value == "" {
+		return fmt.Errorf("output format %s requires a template", OutputTemplate)
+	}
+	_, err := parseTemplate(value)
+	return err
+}
+
 func validateLimit(value int) error {
 	if value < 0 {
 		return fmt.Errorf("invalid limit: %d", value)
@@ -68,6 +202,24 @@ func validateLimit(value int) error {
 
 }
 
+func validatePagination(limit int, offset int, page int, pageSize int) error {
+	switch {
+	case offset < 0:
+		return fmt.Errorf("invalid offset: %d", offset)
+	case page < 0:
+		return fmt.Errorf("invalid page: %d", page)
+	case pageSize < 0:
+		return fmt.Errorf("invalid page size: %d", pageSize)
+	case page > 0 && pageSize == 0:
+		return fmt.Errorf("--%s requires --%s", FlagPage, FlagPageSize)
+	case pageSize > 0 && offset > 0:
+		return fmt.Errorf("cannot use --%s and --%s together", FlagOffset, FlagPageSize)
+	case pageSize > 0 && limit > 0:
+		return fmt.Errorf("cannot use --%s and --%s together", FlagLimit, FlagPageSize)
+	}
+	return nil
+}
+
 func validateOrder(value string) error {
 	switch value {
 	case OrderAsc, OrderDesc:
@@ -79,18 +231,27 @@ func validateOrder(value string) error {
 
 func validateOutput(value string) error {
 	switch value {
-	case OutputNone, OutputJson, OutputTable, OutputYaml:
+	case OutputCsv, OutputJson, OutputJsonl, OutputMarkdown, OutputNone, OutputTable, OutputTemplate, OutputYaml:
 		return nil
 	default:
 		return fmt.Errorf("invalid output format: %s", value)
 	}
 }
 
-func validateSortBy(value string) error {
-	switch value {
-	case FieldTimestamp, FieldUsername:
-		return nil
-	default:
-		return fmt.Errorf("invalid sort by field: %s", value)
+func validateSortBy(value string, fields []string) error {
+	if !slices.Contains(fields, value) {
+		return fmt.Errorf("invalid sort by field: %s, must be one of: %s", value, strings.Join(fields, ", "))
 	}
+	return nil
+}
+
+func (o *Options) Window() (int, int) {
+	if o.PageSize > 0 {
+		return (max(o.Page, 1) - 1) * o.PageSize, o.PageSize
+	}
+	return o.Offset, o.Limit
+}
+
+func (o *Options) paginated() bool {
+	return o.Limit > 0 || o.Offset > 0 || o.PageSize > 0
 }
@@ -1,10 +1,13 @@
 package instagram
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
-	"github.com/spf13/pflag"
+	"github.com/spf13/cobra"
 )
 
 func Test_validateOutput(t *testing.T) {
@@ -42,15 +45,22 @@ func Test_validateOutput(t *testing.T) {
 
 func TestOptions_Validate(t *testing.T) {
 	type fields struct {
-		Limit  int
-		Order  string
-		Output string
-		SortBy string
+		Columns    []string
+		Limit      int
+		Offset     int
+		Order      string
+		Output     string
+		Page       int
+		PageSize   int
+		SortBy     string
+		Template   string
+		flagSortBy string
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		wantErr bool
+		name       string
+		fields     fields
+		wantSortBy string
+		wantErr    bool
 	}{
 		{
 			name: "succeeds to validate all options",
@@ -69,6 +79,54 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "succeeds to validate pagination",
+			fields: fields{
+				Order:    OrderAsc,
+				Output:   OutputTable,
+				Page:     2,
+				PageSize: 50,
+				SortBy:   FieldTimestamp,
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to validate offset",
+			fields: fields{
+				Offset: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate page size",
+			fields: fields{
+				PageSize: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate page without page size",
+			fields: fields{
+				Page: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate offset with page size",
+			fields: fields{
+				Offset:   10,
+				PageSize: 50,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate limit with page size",
+			fields: fields{
+				Limit:    10,
+				PageSize: 50,
+			},
+			wantErr: true,
+		},
 		{
 			name: "fails to validate order",
 			fields: fields{
@@ -93,103 +151,332 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "falls back to flag sort by when default is unsupported by command",
+			fields: fields{
+				Order:      OrderAsc,
+				Output:     OutputTable,
+				SortBy:     FieldCount,
+				flagSortBy: FieldUsername,
+			},
+			wantSortBy: FieldUsername,
+			wantErr:    false,
+		},
+		{
+			name: "keeps default sort by supported by command",
+			fields: fields{
+				Order:      OrderAsc,
+				Output:     OutputTable,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldUsername,
+			},
+			wantSortBy: FieldTimestamp,
+			wantErr:    false,
+		},
+		{
+			name: "fails to validate sort by field unsupported by command",
+			fields: fields{
+				Order:  OrderAsc,
+				Output: OutputTable,
+				SortBy: FieldCount,
+			},
+			wantErr: true,
+		},
+		{
+			name: "succeeds to validate template",
+			fields: fields{
+				Order:    OrderAsc,
+				Output:   OutputTemplate,
+				SortBy:   FieldUsername,
+				Template: "{{.Username}}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to validate columns",
+			fields: fields{
+				Columns: []string{"username", " "},
+				Order:   OrderAsc,
+				Output:  OutputTable,
+				SortBy:  FieldUsername,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate missing template",
+			fields: fields{
+				Order:  OrderAsc,
+				Output: OutputTemplate,
+				SortBy: FieldUsername,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate template without template output",
+			fields: fields{
+				Order:    OrderAsc,
+				Output:   OutputTable,
+				SortBy:   FieldUsername,
+				Template: "{{.Username}}",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse template",
+			fields: fields{
+				Order:    OrderAsc,
+				Output:   OutputTemplate,
+				SortBy:   FieldUsername,
+				Template: "{{.Username",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := Options{
-				Limit:  tt.fields.Limit,
-				Order:  tt.fields.Order,
-				Output: tt.fields.Output,
-				SortBy: tt.fields.SortBy,
+				Columns:    tt.fields.Columns,
+				Limit:      tt.fields.Limit,
+				Offset:     tt.fields.Offset,
+				Order:      tt.fields.Order,
+				Output:     tt.fields.Output,
+				Page:       tt.fields.Page,
+				PageSize:   tt.fields.PageSize,
+				SortBy:     tt.fields.SortBy,
+				Template:   tt.fields.Template,
+				flagSortBy: tt.fields.flagSortBy,
 			}
-			if err := o.Validate(); (err != nil) != tt.wantErr {
+			if err := o.Validate(FieldTimestamp, FieldUsername); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantSortBy != "" {
+				if o.SortBy != tt.wantSortBy {
+					t.Errorf("Validate() sort by = %v, want %v", o.SortBy, tt.wantSortBy)
+				}
+			}
 		})
 	}
 }
 
+func TestAddCommonFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddCommonFlags(cmd, OrderAsc, FieldName, FieldCount, FieldName)
+	for _, name := range []string{FlagColumns, FlagLimit, FlagOffset, FlagOrder, FlagOutput, FlagPage, FlagPageSize, FlagSortBy, FlagTemplate, FlagTemplateFile} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("AddCommonFlags() missing flag %s", name)
+		}
+	}
+	if got := cmd.Flags().Lookup(FlagOrder).DefValue; got != OrderAsc {
+		t.Errorf("AddCommonFlags() order = %v, want %v", got, OrderAsc)
+	}
+	if got := cmd.Flags().Lookup(FlagSortBy).DefValue; got != FieldName {
+		t.Errorf("AddCommonFlags() sort by = %v, want %v", got, FieldName)
+	}
+	if got, want := cmd.Flags().Lookup(FlagSortBy).Usage, `sort by field ("count", "name")`; got != want {
+		t.Errorf("AddCommonFlags() sort by usage = %v, want %v", got, want)
+	}
+	opts, err := NewOptions(cmd)
+	if err != nil {
+		t.Fatalf("NewOptions() error = %v", err)
+	}
+	if err = opts.Validate(FieldCount, FieldName); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
 func TestNewOptions(t *testing.T) {
-	type args struct {
-		flags *pflag.FlagSet
+	newCommand := func(config *Config, args ...string) *cobra.Command {
+		root := &cobra.Command{Use: "instagram"}
+		cmd := &cobra.Command{Use: "followers"}
+		cmd.Flags().Int(FlagLimit, 1000, "")
+		cmd.Flags().String(FlagOrder, OrderAsc, "")
+		cmd.Flags().String(FlagOutput, OutputTable, "")
+		cmd.Flags().String(FlagSortBy, FieldTimestamp, "")
+		cmd.Flags().Int(FlagOffset, 0, "")
+		cmd.Flags().Int(FlagPage, 0, "")
+		cmd.Flags().Int(FlagPageSize, 0, "")
+		cmd.Flags().StringSlice(FlagColumns, nil, "")
+		cmd.Flags().String(FlagTemplate, "", "")
+		cmd.Flags().String(FlagTemplateFile, "", "")
+		root.AddCommand(cmd)
+		cmd.SetContext(WithConfig(context.Background(), config))
+		_ = cmd.Flags().Parse(args)
+		return cmd
 	}
 	tests := []struct {
 		name    string
-		args    args
+		cmd     *cobra.Command
+		env     map[string]string
 		want    *Options
 		wantErr bool
 	}{
 		{
 			name: "succeeds to create options",
-			args: args{
-				flags: func() *pflag.FlagSet {
-					flags := pflag.NewFlagSet("", pflag.ExitOnError)
-					flags.Int(FlagLimit, 1000, "")
-					flags.String(FlagOrder, OrderAsc, "")
-					flags.String(FlagOutput, OutputTable, "")
-					flags.String(FlagSortBy, FieldTimestamp, "")
-					return flags
-				}(),
+			cmd:  newCommand(nil),
+			want: &Options{
+				Columns:    []string{},
+				Limit:      1000,
+				Order:      OrderAsc,
+				Output:     OutputTable,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldTimestamp,
 			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to resolve defaults from config",
+			cmd: newCommand(&Config{
+				Defaults: Defaults{Limit: func() *int { limit := 5; return &limit }(), Output: OutputJson},
+				Commands: map[string]Defaults{"followers": {Output: OutputYaml, SortBy: FieldUsername}},
+			}),
 			want: &Options{
-				Limit:  1000,
-				Order:  OrderAsc,
-				Output: OutputTable,
-				SortBy: FieldTimestamp,
+				Columns:    []string{},
+				Limit:      5,
+				Order:      OrderAsc,
+				Output:     OutputYaml,
+				SortBy:     FieldUsername,
+				flagSortBy: FieldTimestamp,
 			},
 			wantErr: false,
 		},
 		{
-			name: "fails to find flag limit",
-			args: args{
-				flags: pflag.NewFlagSet("", pflag.ExitOnError),
+			name: "succeeds to resolve env before config",
+			cmd:  newCommand(&Config{Defaults: Defaults{Order: OrderAsc, Output: OutputJson}}),
+			env: map[string]string{
+				"INSTAGRAM_ORDER":            OrderDesc,
+				"INSTAGRAM_OUTPUT":           OutputCsv,
+				"INSTAGRAM_FOLLOWERS_OUTPUT": OutputJsonl,
+			},
+			want: &Options{
+				Columns:    []string{},
+				Limit:      1000,
+				Order:      OrderDesc,
+				Output:     OutputJsonl,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldTimestamp,
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to resolve flags before env and config",
+			cmd:  newCommand(&Config{Defaults: Defaults{Output: OutputJson}}, "--output", OutputMarkdown, "--limit", "0"),
+			env: map[string]string{
+				"INSTAGRAM_LIMIT":  "10",
+				"INSTAGRAM_OUTPUT": OutputCsv,
+			},
+			want: &Options{
+				Columns:    []string{},
+				Limit:      Unlimited,
+				Order:      OrderAsc,
+				Output:     OutputMarkdown,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldTimestamp,
 			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to read columns and template file",
+			cmd: newCommand(nil, "--columns", "username,timestamp", "--output", OutputTemplate, "--template-file", func() string {
+				path := filepath.Join(t.TempDir(), "template.tmpl")
+				if err := os.WriteFile(path, []byte("{{.Username}}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return path
+			}()),
+			want: &Options{
+				Columns:    []string{FieldUsername, FieldTimestamp},
+				Limit:      1000,
+				Order:      OrderAsc,
+				Output:     OutputTemplate,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldTimestamp,
+				Template:   "{{.Username}}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to read pagination",
+			cmd:  newCommand(nil, "--offset", "10", "--page", "2", "--page-size", "50"),
+			want: &Options{
+				Columns:    []string{},
+				Limit:      1000,
+				Offset:     10,
+				Order:      OrderAsc,
+				Output:     OutputTable,
+				Page:       2,
+				PageSize:   50,
+				SortBy:     FieldTimestamp,
+				flagSortBy: FieldTimestamp,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "fails to combine template and template file",
+			cmd:     newCommand(nil, "--template", "{{.Username}}", "--template-file", "template.tmpl"),
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "fails to find flag order",
-			args: args{
-				flags: func() *pflag.FlagSet {
-					flags := pflag.NewFlagSet("", pflag.ExitOnError)
-					flags.Int(FlagLimit, Unlimited, "")
-					return flags
-				}(),
+			name:    "fails to read template file",
+			cmd:     newCommand(nil, "--template-file", filepath.Join(t.TempDir(), "missing.tmpl")),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to parse limit from env",
+			cmd:  newCommand(nil),
+			env: map[string]string{
+				"INSTAGRAM_LIMIT": "invalid",
 			},
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "fails to find flag limit",
+			cmd:     &cobra.Command{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fails to find flag order",
+			cmd: func() *cobra.Command {
+				cmd := &cobra.Command{}
+				cmd.Flags().Int(FlagLimit, Unlimited, "")
+				return cmd
+			}(),
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "fails to find flag output",
-			args: args{
-				flags: func() *pflag.FlagSet {
-					flags := pflag.NewFlagSet("", pflag.ExitOnError)
-					flags.Int(FlagLimit, Unlimited, "")
-					flags.String(FlagOrder, OrderAsc, "")
-					return flags
-				}(),
-			},
+			cmd: func() *cobra.Command {
+				cmd := &cobra.Command{}
+				cmd.Flags().Int(FlagLimit, Unlimited, "")
+				cmd.Flags().String(FlagOrder, OrderAsc, "")
+				return cmd
+			}(),
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name: "fails to find flag sort by",
-			args: args{
-				flags: func() *pflag.FlagSet {
-					flags := pflag.NewFlagSet("", pflag.ExitOnError)
-					flags.Int(FlagLimit, Unlimited, "")
-					flags.String(FlagOrder, OrderAsc, "")
-					flags.String(FlagOutput, OutputTable, "")
-					return flags
-				}(),
-			},
+			cmd: func() *cobra.Command {
+				cmd := &cobra.Command{}
+				cmd.Flags().Int(FlagLimit, Unlimited, "")
+				cmd.Flags().String(FlagOrder, OrderAsc, "")
+				cmd.Flags().String(FlagOutput, OutputTable, "")
+				return cmd
+			}(),
 			want:    nil,
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewOptions(tt.args.flags)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			got, err := NewOptions(tt.cmd)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewOptions() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,184 @@
+package instagram
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+type Outputter interface {
+	Data() any
+	TableHeader() table.Row
+	TableRows() []table.Row
+}
+
+func Output(o Outputter, opts *Options) (*string, error) {
+	if opts.Output == OutputTemplate {
+		return outputTemplate(o, opts.Template)
+	}
+	if len(opts.Columns) > 0 {
+		selected, err := selectColumns(o, opts.Output, opts.Columns)
+		if err != nil {
+			return nil, err
+		}
+		o = selected
+	}
+	switch opts.Output {
+	case OutputCsv:
+		return outputCsv(o)
+	case OutputJson:
+		return outputJson(o)
+	case OutputJsonl:
+		return outputJsonl(o)
+	case OutputMarkdown:
+		return outputMarkdown(o)
+	case OutputNone:
+		return outputNone()
+	case OutputTable:
+		return outputTable(o, opts)
+	case OutputYaml:
+		return outputYaml(o)
+	default:
+		return nil, fmt.Errorf("invalid output format: %s", opts.Output)
+	}
+}
+
+func outputCsv(o Outputter) (*string, error) {
+	t := table.NewWriter()
+	t.AppendHeader(o.TableHeader())
+	t.AppendRows(o.TableRows())
+	output := t.RenderCSV()
+	return &output, nil
+}
+
+func outputMarkdown(o Outputter) (*string, error) {
+	t := table.NewWriter()
+	t.AppendHeader(o.TableHeader())
+	t.AppendRows(o.TableRows())
+	output := t.RenderMarkdown()
+	return &output, nil
+}
+
+func outputNone() (*string, error) {
+	output := ""
+	return &output, nil
+}
+
+func outputJson(o Outputter) (*string, error) {
+	data, err := json.MarshalIndent(o.Data(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	output := string(data)
+	return &output, nil
+}
+
+func outputJsonl(o Outputter) (*string, error) {
+	data, err := json.Marshal(o.Data())
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if err = json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	for i := range items {
+		sb.Write(items[i])
+		sb.WriteString("\n")
+	}
+	output := sb.String()
+	return &output, nil
+}
+
+func outputTable(o Outputter, opts *Options) (*string, error) {
+	t := table.NewWriter()
+	t.SetAutoIndex(true)
+	t.SetStyle(table.StyleBold)
+	t.AppendHeader(o.TableHeader())
+	t.AppendRows(o.TableRows())
+	if p := opts.pagination; p != nil && opts.paginated() {
+		t.AppendFooter(table.Row{p.footer()})
+	}
+	output := t.Render()
+	return &output, nil
+}
+
+func (p *pagination) footer() string {
+	if p.start == p.end {
+		return fmt.Sprintf("showing 0 of %d", p.total)
+	}
+	return fmt.Sprintf("showing %d-%d of %d", p.start+1, p.end, p.total)
+}
+
+func outputTemplate(o Outputter, text string) (*string, error) {
+	t, err := parseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]any, 0)
+	data := reflect.ValueOf(o.Data())
+	if data.Kind() == reflect.Slice || data.Kind() == reflect.Array {
+		for i := 0; i < data.Len(); i++ {
+			items = append(items, data.Index(i).Interface())
+		}
+	} else {
+		items = append(items, o.Data())
+	}
+	var sb strings.Builder
+	for i := range items {
+		var item strings.Builder
+		if err = t.Execute(&item, items[i]); err != nil {
+			return nil, err
+		}
+		sb.WriteString(item.String())
+		if !strings.HasSuffix(item.String(), "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	output := sb.String()
+	return &output, nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	t, err := template.New(OutputTemplate).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return t, nil
+}
+
+func outputYaml(o Outputter) (*string, error) {
+	data, err := yaml.Marshal(o.Data())
+	if err != nil {
+		return nil, err
+	}
+	output := string(data)
+	return &output, nil
+}
+
+func Slice[T any](items []T, offset int, limit int) []T {
+	start := min(max(offset, 0), len(items))
+	end := len(items)
+	if limit > 0 {
+		end = min(start+limit, end)
+	}
+	return items[start:end]
+}
+
+func Paginate[T any](items []T, opts *Options) []T {
+	offset, limit := opts.Window()
+	page := Slice(items, offset, limit)
+	start := min(max(offset, 0), len(items))
+	opts.pagination = &pagination{
+		start: start,
+		end:   start + len(page),
+		total: len(items),
+	}
+	return page
+}
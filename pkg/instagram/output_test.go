@@ -0,0 +1,337 @@
+package instagram
+
+import (
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyOutputter struct {
+	data any
+}
+
+func (d *dummyOutputter) Data() any {
+	return d.data
+}
+
+func (d *dummyOutputter) TableHeader() table.Row {
+	return table.Row{TableHeaderUsername}
+}
+
+func (d *dummyOutputter) TableRows() []table.Row {
+	return []table.Row{{"username"}}
+}
+
+type dummyUser struct {
+	Username   string `json:"username" yaml:"username"`
+	ProfileUrl string `json:"profileUrl" yaml:"profileUrl"`
+	Timestamp  int    `json:"timestamp" yaml:"timestamp"`
+}
+
+type dummyUserOutputter struct{}
+
+func (d *dummyUserOutputter) Data() any {
+	return []dummyUser{
+		{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: 1},
+		{Username: "username2", ProfileUrl: "https://www.instagram.com/username2", Timestamp: 2},
+	}
+}
+
+func (d *dummyUserOutputter) TableHeader() table.Row {
+	return table.Row{TableHeaderUsername, TableHeaderProfileUrl, TableHeaderTimestamp}
+}
+
+func (d *dummyUserOutputter) TableRows() []table.Row {
+	return []table.Row{
+		{"username1", "https://www.instagram.com/username1", 1},
+		{"username2", "https://www.instagram.com/username2", 2},
+	}
+}
+
+func TestOutput(t *testing.T) {
+	type args struct {
+		o    Outputter
+		opts *Options
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "succeeds to output csv",
+			args: args{
+				o:    &dummyOutputter{},
+				opts: &Options{Output: OutputCsv},
+			},
+			want:    "USERNAME\nusername",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output json",
+			args: args{
+				o:    &dummyOutputter{data: []string{"username"}},
+				opts: &Options{Output: OutputJson},
+			},
+			want:    "[\n  \"username\"\n]",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output json lines",
+			args: args{
+				o:    &dummyOutputter{data: []map[string]string{{"username": "username1"}, {"username": "username2"}}},
+				opts: &Options{Output: OutputJsonl},
+			},
+			want:    "{\"username\":\"username1\"}\n{\"username\":\"username2\"}\n",
+			wantErr: false,
+		},
+		{
+			name: "fails to output json lines for non-list data",
+			args: args{
+				o:    &dummyOutputter{data: "username"},
+				opts: &Options{Output: OutputJsonl},
+			},
+			wantErr: true,
+		},
+		{
+			name: "succeeds to output markdown",
+			args: args{
+				o:    &dummyOutputter{},
+				opts: &Options{Output: OutputMarkdown},
+			},
+			want:    "| USERNAME |\n| --- |\n| username |",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output none",
+			args: args{
+				o:    &dummyOutputter{},
+				opts: &Options{Output: OutputNone},
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output table",
+			args: args{
+				o:    &dummyOutputter{},
+				opts: &Options{Output: OutputTable},
+			},
+			want:    "┏━━━┳━━━━━━━━━━┓\n┃   ┃ USERNAME ┃\n┣━━━╋━━━━━━━━━━┫\n┃ 1 ┃ username ┃\n┗━━━┻━━━━━━━━━━┛",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output table with pagination footer",
+			args: args{
+				o: &dummyOutputter{},
+				opts: &Options{
+					Output:     OutputTable,
+					Limit:      1,
+					pagination: &pagination{start: 0, end: 1, total: 3},
+				},
+			},
+			want:    "┏━━━┳━━━━━━━━━━━━━━━━━━┓\n┃   ┃ USERNAME         ┃\n┣━━━╋━━━━━━━━━━━━━━━━━━┫\n┃ 1 ┃ username         ┃\n┣━━━╋━━━━━━━━━━━━━━━━━━┫\n┃   ┃ SHOWING 1-1 OF 3 ┃\n┗━━━┻━━━━━━━━━━━━━━━━━━┛",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output yaml",
+			args: args{
+				o:    &dummyOutputter{data: []string{"username"}},
+				opts: &Options{Output: OutputYaml},
+			},
+			want:    "- username\n",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output selected csv columns",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputCsv, Columns: []string{"timestamp", "username"}},
+			},
+			want:    "TIMESTAMP,USERNAME\n1,username1\n2,username2",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output selected json columns",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputJsonl, Columns: []string{"profile-url", "username"}},
+			},
+			want:    "{\"profileUrl\":\"https://www.instagram.com/username1\",\"username\":\"username1\"}\n{\"profileUrl\":\"https://www.instagram.com/username2\",\"username\":\"username2\"}\n",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output selected yaml columns",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputYaml, Columns: []string{"timestamp", "profileUrl"}},
+			},
+			want:    "- timestamp: 1\n  profileUrl: https://www.instagram.com/username1\n- timestamp: 2\n  profileUrl: https://www.instagram.com/username2\n",
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output template",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputTemplate, Template: "{{.Username}}"},
+			},
+			want:    "username1\nusername2\n",
+			wantErr: false,
+		},
+		{
+			name: "fails to select unknown table column",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputTable, Columns: []string{"unknown"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to select unknown json column",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputJson, Columns: []string{"unknown"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to execute template with missing field",
+			args: args{
+				o:    &dummyUserOutputter{},
+				opts: &Options{Output: OutputTemplate, Template: "{{.Unknown}}"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to marshal json",
+			args: args{
+				o:    &dummyOutputter{data: make(chan int)},
+				opts: &Options{Output: OutputJson},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to output invalid format",
+			args: args{
+				o:    &dummyOutputter{},
+				opts: &Options{Output: "invalid"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Output(tt.args.o, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Output() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				assert.Equal(t, tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	type args struct {
+		items  []int
+		offset int
+		limit  int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int
+	}{
+		{
+			name: "succeeds to limit items",
+			args: args{
+				items: []int{1, 2, 3},
+				limit: 2,
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "avoids to limit items when limit is unlimited",
+			args: args{
+				items: []int{1, 2, 3},
+				limit: Unlimited,
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "avoids to limit items when limit exceeds length",
+			args: args{
+				items: []int{1, 2, 3},
+				limit: 5,
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "succeeds to skip items by offset",
+			args: args{
+				items:  []int{1, 2, 3},
+				offset: 1,
+				limit:  1,
+			},
+			want: []int{2},
+		},
+		{
+			name: "succeeds to return no items when offset exceeds length",
+			args: args{
+				items:  []int{1, 2, 3},
+				offset: 5,
+			},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slice(tt.args.items, tt.args.offset, tt.args.limit))
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		name       string
+		opts       *Options
+		want       []int
+		wantFooter string
+	}{
+		{
+			name:       "succeeds to paginate by offset and limit",
+			opts:       &Options{Offset: 1, Limit: 2},
+			want:       []int{2, 3},
+			wantFooter: "showing 2-3 of 5",
+		},
+		{
+			name:       "succeeds to paginate by page",
+			opts:       &Options{Page: 3, PageSize: 2},
+			want:       []int{5},
+			wantFooter: "showing 5-5 of 5",
+		},
+		{
+			name:       "succeeds to default to first page",
+			opts:       &Options{PageSize: 2},
+			want:       []int{1, 2},
+			wantFooter: "showing 1-2 of 5",
+		},
+		{
+			name:       "succeeds to return empty page",
+			opts:       &Options{Page: 4, PageSize: 2},
+			want:       []int{},
+			wantFooter: "showing 0 of 5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Paginate(items, tt.opts))
+			assert.Equal(t, tt.wantFooter, tt.opts.pagination.footer())
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	keyChangeDate    = "Change Date"
+	keyChanged       = "Changed"
+	keyNewValue      = "New Value"
+	keyPreviousValue = "Previous Value"
+)
+
+type Interface interface {
+	History(opts *instagram.Options, reveal bool) (*string, error)
+	Show(opts *instagram.Options, reveal bool) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) History(opts *instagram.Options, reveal bool) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathProfileChanges)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathProfileChanges, err)
+	}
+	cl, err := parseChanges(data, reveal)
+	if err != nil {
+		return nil, err
+	}
+	cl.Sort(opts.SortBy, opts.Order)
+	cl.Paginate(opts)
+	return instagram.Output(cl, opts)
+}
+
+func (h *handler) Show(opts *instagram.Options, reveal bool) (*string, error) {
+	files := []infoFile{
+		{path: instagram.PathPersonalInformation, required: true},
+		{path: instagram.PathAccountInformation},
+	}
+	fl := &fieldList{
+		fields: make([]field, 0),
+	}
+	for _, file := range files {
+		data, err := h.fileSystem.ReadFile(file.path)
+		if err != nil {
+			if !file.required && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, instagram.WrapNotExist(h.fileSystem, file.path, err)
+		}
+		parsed, err := parseFields(data, reveal)
+		if err != nil {
+			return nil, err
+		}
+		fl.fields = append(fl.fields, parsed...)
+	}
+	fl.Sort(opts.SortBy, opts.Order)
+	fl.Paginate(opts)
+	return instagram.Output(fl, opts)
+}
+
+type infoFile struct {
+	path     string
+	required bool
+}
+
+func parseChanges(data []byte, reveal bool) (*changeList, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	cl := &changeList{
+		changes: make([]change, 0),
+	}
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			name := entry.StringMapData[keyChanged].Value
+			cl.changes = append(cl.changes, change{
+				Field:         name,
+				PreviousValue: redact(name, entry.StringMapData[keyPreviousValue].Value, reveal),
+				NewValue:      redact(name, entry.StringMapData[keyNewValue].Value, reveal),
+				Timestamp:     instagram.NewTimestamp(entry.StringMapData[keyChangeDate].Timestamp),
+			})
+		}
+	}
+	return cl, nil
+}
+
+func parseFields(data []byte, reveal bool) ([]field, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	fields := make([]field, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			for name, sd := range entry.StringMapData {
+				value := sd.Value
+				if value == "" && sd.Timestamp != 0 {
+					value = instagram.NewTimestamp(sd.Timestamp).String()
+				}
+				fields = append(fields, field{
+					Field: name,
+					Value: redact(name, value, reveal),
+				})
+			}
+		}
+	}
+	return fields, nil
+}
+
+func isContactField(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "email") || strings.Contains(name, "phone")
+}
+
+func redact(name string, value string, reveal bool) string {
+	if reveal || value == "" || !isContactField(name) {
+		return value
+	}
+	return instagram.Redact(value)
+}
+
+type change struct {
+	Field         string               `json:"field" yaml:"field"`
+	PreviousValue string               `json:"previousValue" yaml:"previousValue"`
+	NewValue      string               `json:"newValue" yaml:"newValue"`
+	Timestamp     *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type changeList struct {
+	changes []change
+}
+
+func (cl *changeList) Data() any {
+	return cl.changes
+}
+
+func (cl *changeList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderField,
+		instagram.TableHeaderPreviousValue,
+		instagram.TableHeaderNewValue,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (cl *changeList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range cl.changes {
+		current := cl.changes[i]
+		rows = append(rows, table.Row{
+			current.Field,
+			current.PreviousValue,
+			current.NewValue,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (cl *changeList) Sort(field string, order string) {
+	sort.SliceStable(cl.changes, func(a, b int) bool {
+		changeOne := cl.changes[a]
+		changeTwo := cl.changes[b]
+		switch field {
+		case instagram.FieldName:
+			return changeOne.Field < changeTwo.Field
+		default:
+			return changeOne.Timestamp.Time.Before(changeTwo.Timestamp.Time)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(cl.changes)
+	}
+}
+
+func (cl *changeList) Paginate(opts *instagram.Options) {
+	cl.changes = instagram.Paginate(cl.changes, opts)
+}
+
+type field struct {
+	Field string `json:"field" yaml:"field"`
+	Value string `json:"value" yaml:"value"`
+}
+
+type fieldList struct {
+	fields []field
+}
+
+func (fl *fieldList) Data() any {
+	return fl.fields
+}
+
+func (fl *fieldList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderField,
+		instagram.TableHeaderValue,
+	}
+}
+
+func (fl *fieldList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range fl.fields {
+		rows = append(rows, table.Row{
+			fl.fields[i].Field,
+			fl.fields[i].Value,
+		})
+	}
+	return rows
+}
+
+func (fl *fieldList) Sort(_ string, order string) {
+	sort.SliceStable(fl.fields, func(a, b int) bool {
+		return fl.fields[a].Field < fl.fields[b].Field
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(fl.fields)
+	}
+}
+
+func (fl *fieldList) Paginate(opts *instagram.Options) {
+	fl.fields = instagram.Paginate(fl.fields, opts)
+}
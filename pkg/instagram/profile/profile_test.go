@@ -0,0 +1,224 @@
+package profile
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	profileChangesJson      = `{"profile_profile_change":[{"title":"","string_map_data":{"Changed":{"value":"Email"},"Previous Value":{"value":"old@example.com"},"New Value":{"value":"new@example.com"},"Change Date":{"timestamp":1696161600}}},{"title":"","string_map_data":{"Changed":{"value":"Username"},"Previous Value":{"value":"username1"},"New Value":{"value":"username2"},"Change Date":{"timestamp":1696248000}}}]}`
+	personalInformationJson = `{"profile_user":[{"string_map_data":{"Email":{"value":"new@example.com"},"Phone Number":{"value":"+10000000000"},"Username":{"value":"username2"},"Bio":{"value":"bio"}}}]}`
+	accountInformationJson  = `{"profile_account_insights":[{"string_map_data":{"Last Login":{"timestamp":1696248000}}}]}`
+)
+
+func Test_handler_History(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output profile history",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathProfileChanges).Return([]byte(profileChangesJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathProfileChanges).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse profile changes",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathProfileChanges).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.History(instagram.NewEmptyOptions(), false); (err != nil) != tt.wantErr {
+				t.Errorf("History() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Show(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output profile",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPersonalInformation).Return([]byte(personalInformationJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountInformation).Return([]byte(accountInformationJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output profile when optional file is missing",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPersonalInformation).Return([]byte(personalInformationJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathAccountInformation).Return(nil, fs.ErrNotExist)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read required file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPersonalInformation).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse personal information",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathPersonalInformation).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Show(instagram.NewEmptyOptions(), false); (err != nil) != tt.wantErr {
+				t.Errorf("Show() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_parseChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal bool
+		want   []change
+	}{
+		{
+			name:   "redacts contact fields",
+			reveal: false,
+			want: []change{
+				{
+					Field:         "Email",
+					PreviousValue: instagram.RedactedValue,
+					NewValue:      instagram.RedactedValue,
+					Timestamp:     instagram.NewTimestamp(1696161600),
+				},
+				{
+					Field:         "Username",
+					PreviousValue: "username1",
+					NewValue:      "username2",
+					Timestamp:     instagram.NewTimestamp(1696248000),
+				},
+			},
+		},
+		{
+			name:   "reveals contact fields",
+			reveal: true,
+			want: []change{
+				{
+					Field:         "Email",
+					PreviousValue: "old@example.com",
+					NewValue:      "new@example.com",
+					Timestamp:     instagram.NewTimestamp(1696161600),
+				},
+				{
+					Field:         "Username",
+					PreviousValue: "username1",
+					NewValue:      "username2",
+					Timestamp:     instagram.NewTimestamp(1696248000),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := parseChanges([]byte(profileChangesJson), tt.reveal)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cl.changes)
+		})
+	}
+}
+
+func Test_parseFields(t *testing.T) {
+	fields, err := parseFields([]byte(personalInformationJson), false)
+	assert.NoError(t, err)
+	fl := &fieldList{
+		fields: fields,
+	}
+	fl.Sort(instagram.FieldName, instagram.OrderAsc)
+	assert.Equal(t, []field{
+		{Field: "Bio", Value: "bio"},
+		{Field: "Email", Value: instagram.RedactedValue},
+		{Field: "Phone Number", Value: instagram.RedactedValue},
+		{Field: "Username", Value: "username2"},
+	}, fl.fields)
+	fields, err = parseFields([]byte(accountInformationJson), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []field{{Field: "Last Login", Value: instagram.NewTimestamp(1696248000).String()}}, fields)
+}
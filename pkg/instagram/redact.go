@@ -0,0 +1,8 @@
+package instagram
+
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
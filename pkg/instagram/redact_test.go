@@ -0,0 +1,31 @@
+package instagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "redacts non-empty value",
+			value: "+10000000000",
+			want:  RedactedValue,
+		},
+		{
+			name:  "keeps empty value",
+			value: "",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Redact(tt.value))
+		})
+	}
+}
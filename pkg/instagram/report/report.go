@@ -0,0 +1,231 @@
+package report
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+)
+
+const (
+	chartHeight  = 240
+	chartPadding = 40
+	chartWidth   = 640
+	monthLayout  = "2006-01"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+type Interface interface {
+	Report(htmlPath string) error
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Report(htmlPath string) error {
+	if htmlPath == "" {
+		return fmt.Errorf("must provide a report destination")
+	}
+	if info, err := os.Stat(htmlPath); err == nil && info.IsDir() {
+		return fmt.Errorf("report destination is a directory: %s", htmlPath)
+	}
+	loader := sdk.NewLoaderWithFs(h.fileSystem)
+	followers, err := loader.Followers(context.Background())
+	if err != nil {
+		return err
+	}
+	following, err := loader.Following(context.Background())
+	if err != nil {
+		return err
+	}
+	unfollowers, err := loader.Unfollowers(context.Background())
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(htmlPath), filepath.Base(htmlPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+	if err = tmpl.Execute(temp, newReport(followers, following, unfollowers, time.Now())); err != nil {
+		temp.Close()
+		return err
+	}
+	if err = temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, htmlPath)
+}
+
+type report struct {
+	GeneratedAt string
+	Charts      []chart
+	Sections    []section
+}
+
+type section struct {
+	Title string
+	Users []user
+}
+
+type user struct {
+	Username   string
+	ProfileUrl string
+	Date       string
+}
+
+type chart struct {
+	Title   string
+	Width   int
+	Height  int
+	Left    int
+	Right   int
+	Top     int
+	Bottom  int
+	Points  string
+	Dots    []dot
+	XLabels []label
+	YLabels []label
+}
+
+type dot struct {
+	X int
+	Y int
+}
+
+type label struct {
+	X    int
+	Y    int
+	Text string
+}
+
+func newReport(followers []sdk.Account, following []sdk.Account, unfollowers []sdk.Account, now time.Time) report {
+	return report{
+		GeneratedAt: now.Format(time.RFC1123),
+		Charts: []chart{
+			newChart("Followers growth", followers),
+			newChart("Following growth", following),
+		},
+		Sections: []section{
+			newSection("Followers", followers),
+			newSection("Following", following),
+			newSection("Unfollowers", unfollowers),
+		},
+	}
+}
+
+func newSection(title string, accounts []sdk.Account) section {
+	users := make([]user, 0, len(accounts))
+	sorted := slices.Clone(accounts)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].Timestamp.After(sorted[b].Timestamp)
+	})
+	for _, a := range sorted {
+		profileUrl := a.ProfileUrl
+		if profileUrl == "" {
+			profileUrl = fmt.Sprintf(instagram.ProfileUrlFormat, a.Username)
+		}
+		users = append(users, user{
+			Username:   a.Username,
+			ProfileUrl: profileUrl,
+			Date:       a.Timestamp.Format(time.DateOnly),
+		})
+	}
+	return section{
+		Title: fmt.Sprintf("%s (%d)", title, len(users)),
+		Users: users,
+	}
+}
+
+func newChart(title string, accounts []sdk.Account) chart {
+	c := chart{
+		Title:  title,
+		Width:  chartWidth,
+		Height: chartHeight,
+		Left:   chartPadding,
+		Right:  chartWidth - chartPadding,
+		Top:    chartPadding,
+		Bottom: chartHeight - chartPadding,
+	}
+	months, totals := cumulativeByMonth(accounts)
+	if len(months) == 0 {
+		return c
+	}
+	peak := totals[len(totals)-1]
+	innerWidth, innerHeight := c.Right-c.Left, c.Bottom-c.Top
+	points := make([]string, 0, len(totals))
+	for i, total := range totals {
+		x := c.Left + innerWidth/2
+		if len(totals) > 1 {
+			x = c.Left + i*innerWidth/(len(totals)-1)
+		}
+		y := c.Bottom - total*innerHeight/peak
+		points = append(points, fmt.Sprintf("%d,%d", x, y))
+		c.Dots = append(c.Dots, dot{X: x, Y: y})
+	}
+	c.Points = strings.Join(points, " ")
+	c.XLabels = []label{
+		{X: c.Left, Y: c.Bottom + chartPadding/2, Text: months[0]},
+		{X: c.Right, Y: c.Bottom + chartPadding/2, Text: months[len(months)-1]},
+	}
+	c.YLabels = []label{
+		{X: c.Left - 8, Y: c.Bottom, Text: "0"},
+		{X: c.Left - 8, Y: c.Top, Text: fmt.Sprint(peak)},
+	}
+	return c
+}
+
+func cumulativeByMonth(accounts []sdk.Account) ([]string, []int) {
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	counts := make(map[string]int)
+	first, last := accounts[0].Timestamp, accounts[0].Timestamp
+	for _, a := range accounts {
+		counts[a.Timestamp.Format(monthLayout)]++
+		if a.Timestamp.Before(first) {
+			first = a.Timestamp
+		}
+		if a.Timestamp.After(last) {
+			last = a.Timestamp
+		}
+	}
+	months := make([]string, 0)
+	totals := make([]int, 0)
+	total := 0
+	end := last.Format(monthLayout)
+	for month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, first.Location()); ; month = month.AddDate(0, 1, 0) {
+		key := month.Format(monthLayout)
+		total += counts[key]
+		months = append(months, key)
+		totals = append(totals, total)
+		if key == end {
+			break
+		}
+	}
+	return months, totals
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	followersJson = `[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1693569600}]},{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":1696161600}]}]`
+	followingJson = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1696075200}]},{"string_list_data":[{"href":"https://www.instagram.com/username3","value":"username3","timestamp":1696248000}]}]}`
+)
+
+func Test_handler_Report(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		htmlPath     string
+		expectations func(f *fields, htmlPath string)
+		assertions   func(t *testing.T, f *fields, htmlPath string)
+		wantErr      bool
+	}{
+		{
+			name:     "succeeds to write report",
+			htmlPath: "out.html",
+			expectations: func(f *fields, htmlPath string) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				assert.NoError(t, os.WriteFile(htmlPath, []byte("previous report"), 0644))
+			},
+			assertions: func(t *testing.T, f *fields, htmlPath string) {
+				temps, err := filepath.Glob(htmlPath + ".*.tmp")
+				assert.NoError(t, err)
+				assert.Empty(t, temps)
+				data, err := os.ReadFile(htmlPath)
+				assert.NoError(t, err)
+				html := string(data)
+				assert.Contains(t, html, "<svg")
+				assert.Contains(t, html, "Unfollowers (1)")
+				assert.Contains(t, html, `<a href="https://www.instagram.com/username3">username3</a>`)
+				assert.NotContains(t, html, "previous report")
+				assert.NotContains(t, html, "<script")
+				assert.NotContains(t, html, `src="http`)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "fails to write report without destination",
+			htmlPath: "",
+			wantErr:  true,
+		},
+		{
+			name:     "fails to write report into directory",
+			htmlPath: "out",
+			expectations: func(f *fields, htmlPath string) {
+				assert.NoError(t, os.MkdirAll(htmlPath, 0755))
+			},
+			assertions: func(t *testing.T, f *fields, htmlPath string) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 0)
+				assert.DirExists(t, htmlPath)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "fails to load follow data",
+			htmlPath: "out.html",
+			expectations: func(f *fields, htmlPath string) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{}, nil)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{}, nil)
+			},
+			assertions: func(t *testing.T, f *fields, htmlPath string) {
+				assert.NoFileExists(t, htmlPath)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "fails to create report in missing directory",
+			htmlPath: filepath.Join("missing", "out.html"),
+			expectations: func(f *fields, htmlPath string) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			assertions: func(t *testing.T, f *fields, htmlPath string) {
+				assert.NoFileExists(t, htmlPath)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			htmlPath := tt.htmlPath
+			if htmlPath != "" {
+				htmlPath = filepath.Join(t.TempDir(), htmlPath)
+			}
+			if tt.expectations != nil {
+				tt.expectations(f, htmlPath)
+			}
+			if err := h.Report(htmlPath); (err != nil) != tt.wantErr {
+				t.Errorf("Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f, htmlPath)
+			}
+		})
+	}
+}
+
+func Test_newChart(t *testing.T) {
+	tests := []struct {
+		name       string
+		accounts   []sdk.Account
+		wantPoints string
+		wantLabels []string
+	}{
+		{
+			name:       "returns empty chart without events",
+			accounts:   nil,
+			wantPoints: "",
+		},
+		{
+			name: "centers a single month",
+			accounts: []sdk.Account{
+				{Timestamp: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			wantPoints: "320,40",
+			wantLabels: []string{"2023-10", "2023-10"},
+		},
+		{
+			name: "accumulates months including gaps",
+			accounts: []sdk.Account{
+				{Timestamp: time.Date(2023, time.December, 5, 0, 0, 0, 0, time.UTC)},
+				{Timestamp: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)},
+				{Timestamp: time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)},
+				{Timestamp: time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			wantPoints: "40,120 320,120 600,40",
+			wantLabels: []string{"2023-10", "2023-12"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newChart("title", tt.accounts)
+			assert.Equal(t, tt.wantPoints, got.Points)
+			labels := make([]string, 0)
+			for _, l := range got.XLabels {
+				labels = append(labels, l.Text)
+			}
+			if tt.wantLabels != nil {
+				assert.Equal(t, tt.wantLabels, labels)
+			}
+		})
+	}
+}
+
+func Test_newReport(t *testing.T) {
+	followers := []sdk.Account{
+		{Username: "Username1", ProfileUrl: "https://www.instagram.com/_u/Username1", Timestamp: time.Unix(1693569600, 0)},
+	}
+	following := []sdk.Account{
+		{Username: "username1", ProfileUrl: "https://www.instagram.com/_u/username1", Timestamp: time.Unix(1696075200, 0)},
+		{Username: "username3", ProfileUrl: "https://www.instagram.com/_u/username3", Timestamp: time.Unix(1696248000, 0)},
+	}
+	unfollowers := sdk.NewAccountSet(following).Difference(sdk.NewAccountSet(followers)).Accounts()
+	got := newReport(followers, following, unfollowers, time.Unix(1696248000, 0))
+	assert.Len(t, got.Charts, 2)
+	assert.Equal(t, []string{"Followers (1)", "Following (2)", "Unfollowers (1)"}, []string{got.Sections[0].Title, got.Sections[1].Title, got.Sections[2].Title})
+	assert.Equal(t, "username3", got.Sections[1].Users[0].Username)
+	assert.Equal(t, "username3", got.Sections[2].Users[0].Username)
+	assert.Equal(t, "https://www.instagram.com/_u/username3", got.Sections[2].Users[0].ProfileUrl)
+}
+
+func Test_newSection(t *testing.T) {
+	got := newSection("Followers", []sdk.Account{
+		{Username: "username1", Timestamp: time.Unix(1, 0)},
+		{Username: "username2", ProfileUrl: "https://www.instagram.com/_u/username2", Timestamp: time.Unix(2, 0)},
+	})
+	assert.Equal(t, "Followers (2)", got.Title)
+	assert.Equal(t, []user{
+		{Username: "username2", ProfileUrl: "https://www.instagram.com/_u/username2", Date: time.Unix(2, 0).Format(time.DateOnly)},
+		{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Date: time.Unix(1, 0).Format(time.DateOnly)},
+	}, got.Users)
+}
@@ -0,0 +1,258 @@
+package saved
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	collectionTitle = "Collection"
+	keyAddedTime    = "Added Time"
+	keyName         = "Name"
+	keySavedOn      = "Saved on"
+)
+
+type Interface interface {
+	Collections(opts *instagram.Options) (*string, error)
+	Posts(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Collections(opts *instagram.Options) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathSavedCollections)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathSavedCollections, err)
+	}
+	cl, err := parseCollections(data)
+	if err != nil {
+		return nil, err
+	}
+	cl.Sort(opts.SortBy, opts.Order)
+	cl.Paginate(opts)
+	return instagram.Output(cl, opts)
+}
+
+func (h *handler) Posts(opts *instagram.Options) (*string, error) {
+	data, err := h.fileSystem.ReadFile(instagram.PathSavedPosts)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathSavedPosts, err)
+	}
+	pl, err := parsePosts(data)
+	if err != nil {
+		return nil, err
+	}
+	pl.Sort(opts.SortBy, opts.Order)
+	pl.Paginate(opts)
+	return instagram.Output(pl, opts)
+}
+
+func parsePosts(data []byte) (*postList, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	pl := &postList{
+		posts: make([]post, 0),
+	}
+	for _, entry := range jsonData["saved_saved_media"] {
+		savedOn := entry.StringMapData[keySavedOn]
+		pl.posts = append(pl.posts, post{
+			Username:  entry.Title,
+			Url:       savedOn.Href,
+			Timestamp: instagram.NewTimestamp(savedOn.Timestamp),
+		})
+	}
+	return pl, nil
+}
+
+func parseCollections(data []byte) (*collectionList, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	cl := &collectionList{
+		collections: make([]collection, 0),
+	}
+	for _, entry := range jsonData["saved_saved_collections"] {
+		name := entry.StringMapData[keyName]
+		if entry.Title == collectionTitle {
+			cl.collections = append(cl.collections, collection{
+				Name:  name.Value,
+				Posts: make([]post, 0),
+			})
+			continue
+		}
+		if len(cl.collections) == 0 {
+			cl.collections = append(cl.collections, collection{
+				Posts: make([]post, 0),
+			})
+		}
+		username := entry.Title
+		if username == "" {
+			username = name.Value
+		}
+		current := &cl.collections[len(cl.collections)-1]
+		current.Posts = append(current.Posts, post{
+			Username:  username,
+			Url:       name.Href,
+			Timestamp: instagram.NewTimestamp(entry.StringMapData[keyAddedTime].Timestamp),
+		})
+	}
+	return cl, nil
+}
+
+type post struct {
+	Username  string               `json:"username" yaml:"username"`
+	Url       string               `json:"url" yaml:"url"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type postList struct {
+	posts []post
+}
+
+func (pl *postList) Data() any {
+	return pl.posts
+}
+
+func (pl *postList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderUrl,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (pl *postList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range pl.posts {
+		current := pl.posts[i]
+		rows = append(rows, table.Row{
+			current.Username,
+			current.Url,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (pl *postList) Sort(field string, order string) {
+	sortPosts(pl.posts, field, order)
+}
+
+func (pl *postList) Paginate(opts *instagram.Options) {
+	pl.posts = instagram.Paginate(pl.posts, opts)
+}
+
+type collection struct {
+	Name  string `json:"name" yaml:"name"`
+	Posts []post `json:"posts" yaml:"posts"`
+}
+
+type collectionList struct {
+	collections []collection
+}
+
+func (cl *collectionList) Data() any {
+	return cl.collections
+}
+
+func (cl *collectionList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderCollection,
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderUrl,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (cl *collectionList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range cl.collections {
+		current := cl.collections[i]
+		for j := range current.Posts {
+			rows = append(rows, table.Row{
+				current.Name,
+				current.Posts[j].Username,
+				current.Posts[j].Url,
+				current.Posts[j].Timestamp,
+			})
+		}
+	}
+	return rows
+}
+
+func (cl *collectionList) Sort(field string, order string) {
+	sort.SliceStable(cl.collections, func(a, b int) bool {
+		return cl.collections[a].Name < cl.collections[b].Name
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(cl.collections)
+	}
+	for i := range cl.collections {
+		sortPosts(cl.collections[i].Posts, field, order)
+	}
+}
+
+type postRef struct {
+	collection int
+	post       int
+}
+
+func (cl *collectionList) Paginate(opts *instagram.Options) {
+	refs := make([]postRef, 0)
+	for i := range cl.collections {
+		for j := range cl.collections[i].Posts {
+			refs = append(refs, postRef{collection: i, post: j})
+		}
+	}
+	page := instagram.Paginate(refs, opts)
+	if offset, limit := opts.Window(); offset == 0 && limit == 0 {
+		return
+	}
+	collections := make([]collection, 0, len(cl.collections))
+	previous := -1
+	for _, ref := range page {
+		current := cl.collections[ref.collection]
+		if ref.collection != previous {
+			collections = append(collections, collection{
+				Name:  current.Name,
+				Posts: make([]post, 0),
+			})
+			previous = ref.collection
+		}
+		last := &collections[len(collections)-1]
+		last.Posts = append(last.Posts, current.Posts[ref.post])
+	}
+	cl.collections = collections
+}
+
+func sortPosts(posts []post, field string, order string) {
+	sort.SliceStable(posts, func(a, b int) bool {
+		postOne := posts[a]
+		postTwo := posts[b]
+		switch field {
+		case instagram.FieldUsername:
+			return postOne.Username < postTwo.Username
+		default:
+			return postOne.Timestamp.Time.Before(postTwo.Timestamp.Time)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(posts)
+	}
+}
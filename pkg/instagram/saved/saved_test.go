@@ -0,0 +1,233 @@
+package saved
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	savedPostsJson       = `{"saved_saved_media":[{"title":"username1","string_map_data":{"Saved on":{"href":"https://www.instagram.com/p/1/","timestamp":1696161600}}},{"title":"username2","string_map_data":{"Saved on":{"href":"https://www.instagram.com/p/2/","timestamp":1696248000}}}]}`
+	savedCollectionsJson = `{"saved_saved_collections":[{"title":"Collection","string_map_data":{"Name":{"value":"recipes"},"Creation Time":{"timestamp":1696161600}}},{"title":"username1","string_map_data":{"Name":{"href":"https://www.instagram.com/p/1/","value":"username1"},"Added Time":{"timestamp":1696161600}}},{"title":"Collection","string_map_data":{"Name":{"value":"gear"}}},{"title":"","string_map_data":{"Name":{"href":"https://www.instagram.com/p/2/","value":"username2"},"Added Time":{"timestamp":1696248000}}}]}`
+)
+
+func Test_handler_Posts(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output saved posts",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedPosts).Return([]byte(savedPostsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedPosts).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse saved posts",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedPosts).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Posts(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Posts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Collections(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output saved collections",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedCollections).Return([]byte(savedCollectionsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedCollections).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse saved collections",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathSavedCollections).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Collections(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Collections() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_parseCollections(t *testing.T) {
+	cl, err := parseCollections([]byte(savedCollectionsJson))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(cl.collections))
+	assert.Equal(t, "recipes", cl.collections[0].Name)
+	assert.Equal(t, "username1", cl.collections[0].Posts[0].Username)
+	assert.Equal(t, "gear", cl.collections[1].Name)
+	assert.Equal(t, "username2", cl.collections[1].Posts[0].Username)
+	assert.Equal(t, "https://www.instagram.com/p/2/", cl.collections[1].Posts[0].Url)
+	cl.Sort(instagram.FieldTimestamp, instagram.OrderAsc)
+	assert.Equal(t, "gear", cl.collections[0].Name)
+	cl.Sort(instagram.FieldTimestamp, instagram.OrderDesc)
+	assert.Equal(t, "recipes", cl.collections[0].Name)
+	assert.Equal(t, 2, len(cl.TableRows()))
+}
+
+func Test_collectionList_Paginate(t *testing.T) {
+	newCollectionList := func() *collectionList {
+		return &collectionList{
+			collections: []collection{
+				{Name: "empty", Posts: []post{}},
+				{Name: "gear", Posts: []post{{Username: "username1"}, {Username: "username2"}}},
+				{Name: "recipes", Posts: []post{{Username: "username3"}}},
+				{Name: "travel", Posts: []post{{Username: "username4"}, {Username: "username5"}}},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		opts       *instagram.Options
+		want       map[string][]string
+		wantFooter string
+	}{
+		{
+			name: "succeeds to keep all collections when unlimited",
+			opts: &instagram.Options{Output: instagram.OutputTable},
+			want: map[string][]string{
+				"empty":   {},
+				"gear":    {"username1", "username2"},
+				"recipes": {"username3"},
+				"travel":  {"username4", "username5"},
+			},
+		},
+		{
+			name: "succeeds to limit posts across collections",
+			opts: &instagram.Options{Output: instagram.OutputTable, Offset: 1, Limit: 3},
+			want: map[string][]string{
+				"gear":    {"username2"},
+				"recipes": {"username3"},
+				"travel":  {"username4"},
+			},
+			wantFooter: "SHOWING 2-4 OF 5",
+		},
+		{
+			name: "succeeds to page posts across collections",
+			opts: &instagram.Options{Output: instagram.OutputTable, Page: 3, PageSize: 2},
+			want: map[string][]string{
+				"travel": {"username5"},
+			},
+			wantFooter: "SHOWING 5-5 OF 5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := newCollectionList()
+			cl.Paginate(tt.opts)
+			got := make(map[string][]string)
+			for _, c := range cl.collections {
+				got[c.Name] = make([]string, 0)
+				for _, p := range c.Posts {
+					got[c.Name] = append(got[c.Name], p.Username)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+			output, err := instagram.Output(cl, tt.opts)
+			assert.NoError(t, err)
+			if tt.wantFooter != "" {
+				assert.Contains(t, *output, tt.wantFooter)
+			}
+		})
+	}
+}
+
+func Test_postList_Sort(t *testing.T) {
+	pl, err := parsePosts([]byte(savedPostsJson))
+	assert.NoError(t, err)
+	pl.Sort(instagram.FieldTimestamp, instagram.OrderDesc)
+	assert.Equal(t, "username2", pl.posts[0].Username)
+	pl.Sort(instagram.FieldUsername, instagram.OrderAsc)
+	assert.Equal(t, "username1", pl.posts[0].Username)
+	pl.Paginate(&instagram.Options{Limit: 1})
+	assert.Equal(t, 1, len(pl.posts))
+}
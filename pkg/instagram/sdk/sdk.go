@@ -0,0 +1,366 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+)
+
+const keyRelationshipsFollowing = "relationships_following"
+
+type Account struct {
+	Username   string    `json:"username" yaml:"username"`
+	ProfileUrl string    `json:"profileUrl" yaml:"profileUrl"`
+	Timestamp  time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+type Relationship struct {
+	Account         `yaml:",inline"`
+	Follower        bool      `json:"follower" yaml:"follower"`
+	Following       bool      `json:"following" yaml:"following"`
+	Likes           int       `json:"likes" yaml:"likes"`
+	Comments        int       `json:"comments" yaml:"comments"`
+	Messages        int       `json:"messages" yaml:"messages"`
+	Stories         int       `json:"stories" yaml:"stories"`
+	LastInteraction time.Time `json:"lastInteraction" yaml:"lastInteraction"`
+}
+
+func (r Relationship) Interactions() int {
+	return r.Likes + r.Comments + r.Messages + r.Stories
+}
+
+func (r Relationship) Mutual() bool {
+	return r.Follower && r.Following
+}
+
+type Loader struct {
+	fileSystem filesystem.Fs
+	workers    int
+	followers  cache
+	following  cache
+}
+
+type cache struct {
+	mutex    sync.Mutex
+	loaded   bool
+	accounts []Account
+}
+
+func (c *cache) load(load func() ([]Account, error)) ([]Account, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.loaded {
+		accounts, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.accounts = accounts
+		c.loaded = true
+	}
+	return slices.Clone(c.accounts), nil
+}
+
+func NewLoader() *Loader {
+	return NewLoaderWithFs(filesystem.NewFs())
+}
+
+func NewLoaderWithFs(fileSystem filesystem.Fs) *Loader {
+	return &Loader{
+		fileSystem: fileSystem,
+		workers:    runtime.GOMAXPROCS(0),
+	}
+}
+
+func (l *Loader) Followers(ctx context.Context) ([]Account, error) {
+	return l.followers.load(func() ([]Account, error) {
+		files, err := l.followerFiles()
+		if err != nil {
+			return nil, err
+		}
+		return l.decodePages(ctx, files)
+	})
+}
+
+func (l *Loader) Following(ctx context.Context) ([]Account, error) {
+	return l.following.load(func() ([]Account, error) {
+		accounts := make([]Account, 0)
+		err := l.EachFollowing(ctx, func(account Account) error {
+			accounts = append(accounts, account)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return accounts, nil
+	})
+}
+
+func (l *Loader) EachFollower(ctx context.Context, visit func(account Account) error) error {
+	files, err := l.followerFiles()
+	if err != nil {
+		return err
+	}
+	for i := range files {
+		if err = l.decodeFile(ctx, files[i], decodeFollowers, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Loader) followerFiles() ([]string, error) {
+	files, err := l.fileSystem.FindFiles(instagram.PathFollowers)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, instagram.MissingDataError(l.fileSystem, instagram.PathFollowers)
+	}
+	return files, nil
+}
+
+func (l *Loader) decodePages(ctx context.Context, files []string) ([]Account, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pages := make([][]Account, len(files))
+	indexes := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < min(max(l.workers, 1), len(files)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				page := make([]Account, 0)
+				err := l.decodeFile(ctx, files[i], decodeFollowers, func(account Account) error {
+					page = append(page, account)
+					return nil
+				})
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pages[i] = page
+			}
+		}()
+	}
+send:
+	for i := range files {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	size := 0
+	for i := range pages {
+		size += len(pages[i])
+	}
+	accounts := make([]Account, 0, size)
+	for i := range pages {
+		accounts = append(accounts, pages[i]...)
+	}
+	return accounts, nil
+}
+
+func (l *Loader) EachFollowing(ctx context.Context, visit func(account Account) error) error {
+	err := l.decodeFile(ctx, instagram.PathFollowing, decodeFollowing, visit)
+	return instagram.WrapNotExist(l.fileSystem, instagram.PathFollowing, err)
+}
+
+func (l *Loader) decodeFile(ctx context.Context, name string, decode func(r io.Reader, visit func(account Account) error) error, visit func(account Account) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	file, err := l.fileSystem.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return decode(file, func(account Account) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return visit(account)
+	})
+}
+
+func (l *Loader) Unfollowers(ctx context.Context) ([]Account, error) {
+	followers, err := l.Followers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	following, err := l.Following(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountSet(following).Difference(NewAccountSet(followers)).Accounts(), nil
+}
+
+func (l *Loader) Relationships(ctx context.Context) ([]Relationship, error) {
+	followers, err := l.Followers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	following, err := l.Following(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a := newAggregator(followers, following)
+	loaders := []func(fileSystem filesystem.Fs) ([]activity.Event, error){
+		activity.LoadLikes,
+		activity.LoadComments,
+		activity.LoadStoryInteractions,
+	}
+	for _, load := range loaders {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		loaded, err := load(l.fileSystem)
+		if err != nil {
+			return nil, err
+		}
+		for i := range loaded {
+			a.add(loaded[i])
+		}
+	}
+	err = activity.EachMessage(l.fileSystem, func(event activity.Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		a.add(event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.relationships(), nil
+}
+
+type accountData struct {
+	StringListData []instagram.StringData `json:"string_list_data"`
+}
+
+func decodeFollowers(r io.Reader, visit func(account Account) error) error {
+	return instagram.DecodeArray(json.NewDecoder(r), func(ad accountData) error {
+		return visitAccount(ad, visit)
+	})
+}
+
+func decodeFollowing(r io.Reader, visit func(account Account) error) error {
+	dec := json.NewDecoder(r)
+	return instagram.DecodeObject(dec, func(key string) error {
+		if key != keyRelationshipsFollowing {
+			return instagram.SkipValue(dec)
+		}
+		return instagram.DecodeArray(dec, func(ad accountData) error {
+			return visitAccount(ad, visit)
+		})
+	})
+}
+
+func visitAccount(ad accountData, visit func(account Account) error) error {
+	if len(ad.StringListData) == 0 {
+		return nil
+	}
+	sd := ad.StringListData[0]
+	return visit(Account{
+		Username:   sd.Value,
+		ProfileUrl: sd.Href,
+		Timestamp:  time.Unix(sd.Timestamp, 0),
+	})
+}
+
+func aggregate(followers []Account, following []Account, events []activity.Event) []Relationship {
+	a := newAggregator(followers, following)
+	for i := range events {
+		a.add(events[i])
+	}
+	return a.relationships()
+}
+
+type aggregator struct {
+	entries []Relationship
+	indexes map[string]int
+}
+
+func newAggregator(followers []Account, following []Account) *aggregator {
+	a := &aggregator{
+		entries: make([]Relationship, 0),
+		indexes: make(map[string]int),
+	}
+	for _, account := range followers {
+		a.lookup(account).Follower = true
+	}
+	for _, account := range following {
+		a.lookup(account).Following = true
+	}
+	return a
+}
+
+func (a *aggregator) lookup(account Account) *Relationship {
+	key := NormalizeUsername(account.Username)
+	index, ok := a.indexes[key]
+	if !ok {
+		if account.ProfileUrl == "" {
+			account.ProfileUrl = fmt.Sprintf(instagram.ProfileUrlFormat, account.Username)
+		}
+		index = len(a.entries)
+		a.indexes[key] = index
+		a.entries = append(a.entries, Relationship{
+			Account: account,
+		})
+	}
+	return &a.entries[index]
+}
+
+func (a *aggregator) add(event activity.Event) {
+	if event.Username == "" {
+		return
+	}
+	r := a.lookup(Account{Username: event.Username})
+	switch event.Category {
+	case activity.CategoryLike:
+		r.Likes++
+	case activity.CategoryComment:
+		r.Comments++
+	case activity.CategoryMessage:
+		r.Messages++
+	case activity.CategoryStory:
+		r.Stories++
+	}
+	if event.Timestamp.After(r.LastInteraction) {
+		r.LastInteraction = event.Timestamp
+	}
+}
+
+func (a *aggregator) relationships() []Relationship {
+	sort.SliceStable(a.entries, func(i, j int) bool {
+		return a.entries[i].Username < a.entries[j].Username
+	})
+	return a.entries
+}
@@ -0,0 +1,454 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	followersJson = `[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1}]},{"string_list_data":[{"href":"https://www.instagram.com/username2","value":"username2","timestamp":2}]},{"string_list_data":[]}]`
+	followingJson = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":3}]},{"string_list_data":[{"href":"https://www.instagram.com/username3","value":"username3","timestamp":4}]}]}`
+	likesJson     = `{"likes_media_likes":[{"title":"username1","string_list_data":[{"timestamp":1696161600}]},{"title":"username4","string_list_data":[{"timestamp":1696165200}]}]}`
+	messagesJson  = `{"participants":[{"name":"User Five"},{"name":"Me"}],"messages":[{"sender_name":"User Five","timestamp_ms":1696161600000,"content":"hi"}]}`
+	messagesFile  = "instagram_data/your_instagram_activity/messages/inbox/username5_1234567890/message_1.json"
+)
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestLoader_Followers(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectations func(f *filesystem.MockFs)
+		want         []Account
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load followers",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+			},
+			want: []Account{
+				{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: time.Unix(1, 0)},
+				{Username: "username2", ProfileUrl: "https://www.instagram.com/username2", Timestamp: time.Unix(2, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to merge pages in file order",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1", "file2", "file3"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"value":"username1","timestamp":1}]}]`)), nil)
+				f.On("Open", "file2").Return(io.NopCloser(strings.NewReader(`[]`)), nil)
+				f.On("Open", "file3").Return(io.NopCloser(strings.NewReader(`[{"string_list_data":[{"value":"username3","timestamp":3}]},{"string_list_data":[{"value":"username2","timestamp":2}]}]`)), nil)
+			},
+			want: []Account{
+				{Username: "username1", Timestamp: time.Unix(1, 0)},
+				{Username: "username3", Timestamp: time.Unix(3, 0)},
+				{Username: "username2", Timestamp: time.Unix(2, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to find files",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to read file",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(nil, fmt.Errorf("fails to read file"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to read one of many files",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1", "file2"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil).Maybe()
+				f.On("Open", "file2").Return(nil, fmt.Errorf("fails to read file"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse file",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader("invalid")), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails when context is cancelled",
+			ctx:  cancelledContext(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := NewLoaderWithFs(f).Followers(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Followers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoader_cache(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+	f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil).Once()
+	f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil).Once()
+	l := NewLoaderWithFs(f)
+	followers, err := l.Followers(context.Background())
+	assert.NoError(t, err)
+	followers[0].Username = "modified"
+	_, err = l.Unfollowers(context.Background())
+	assert.NoError(t, err)
+	cached, err := l.Followers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "username1", cached[0].Username)
+	f.AssertNumberOfCalls(t, "FindFiles", 1)
+	f.AssertNumberOfCalls(t, "Open", 2)
+}
+
+func TestLoader_Following(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectations func(f *filesystem.MockFs)
+		want         []Account
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load following",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			want: []Account{
+				{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: time.Unix(3, 0)},
+				{Username: "username3", ProfileUrl: "https://www.instagram.com/username3", Timestamp: time.Unix(4, 0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("Open", instagram.PathFollowing).Return(nil, fs.ErrNotExist)
+				f.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse file",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader("invalid")), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails when context is cancelled",
+			ctx:     cancelledContext(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := NewLoaderWithFs(f).Following(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Following() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoader_Unfollowers(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectations func(f *filesystem.MockFs)
+		want         []string
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load unfollowers",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			want:    []string{"username3"},
+			wantErr: false,
+		},
+		{
+			name: "fails to load followers",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load following",
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(nil, fs.ErrNotExist)
+				f.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := NewLoaderWithFs(f).Unfollowers(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unfollowers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			usernames := make([]string, 0)
+			for _, a := range got {
+				usernames = append(usernames, a.Username)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, usernames)
+			}
+		})
+	}
+}
+
+func TestLoader_Relationships(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectations func(f *filesystem.MockFs)
+		want         []string
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to load relationships",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likesJson), nil)
+				f.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			want:    []string{"username1", "username2", "username3", "username4"},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to load relationships from messages",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.On("ReadFile", instagram.PathLikedPosts).Return(nil, fs.ErrNotExist)
+				f.On("FindFiles", instagram.PathMessages).Return([]string{messagesFile}, nil)
+				f.On("Open", messagesFile).Return(io.NopCloser(strings.NewReader(messagesJson)), nil)
+				f.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			want:    []string{"username1", "username2", "username3", "username5"},
+			wantErr: false,
+		},
+		{
+			name: "fails to load messages",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.On("ReadFile", instagram.PathLikedPosts).Return(nil, fs.ErrNotExist)
+				f.On("FindFiles", instagram.PathMessages).Return([]string{messagesFile}, nil)
+				f.On("Open", messagesFile).Return(io.NopCloser(strings.NewReader("invalid")), nil)
+				f.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load activity",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.On("ReadFile", instagram.PathLikedPosts).Return([]byte("invalid"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load following",
+			ctx:  context.Background(),
+			expectations: func(f *filesystem.MockFs) {
+				f.On("FindFiles", instagram.PathFollowers).Return([]string{"file1"}, nil)
+				f.On("Open", "file1").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.On("Open", instagram.PathFollowing).Return(nil, fs.ErrNotExist)
+				f.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filesystem.MockFs{}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			got, err := NewLoaderWithFs(f).Relationships(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Relationships() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			usernames := make([]string, 0)
+			for _, r := range got {
+				usernames = append(usernames, r.Username)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, usernames)
+			}
+		})
+	}
+}
+
+func Test_aggregate(t *testing.T) {
+	followers := []Account{
+		{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: time.Unix(1, 0)},
+	}
+	following := []Account{
+		{Username: "Username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: time.Unix(2, 0)},
+		{Username: "username2", ProfileUrl: "https://www.instagram.com/username2", Timestamp: time.Unix(3, 0)},
+	}
+	events := []activity.Event{
+		{Category: activity.CategoryMessage, Username: "username1", Timestamp: time.Unix(10, 0)},
+		{Category: activity.CategoryComment, Username: "username1", Timestamp: time.Unix(5, 0)},
+		{Category: activity.CategoryLike, Username: "username3", Timestamp: time.Unix(6, 0)},
+		{Category: activity.CategoryStory, Username: "username3", Timestamp: time.Unix(7, 0)},
+		{Category: activity.CategoryMessage, Username: "", Timestamp: time.Unix(8, 0)},
+	}
+	got := aggregate(followers, following, events)
+	assert.Equal(t, []Relationship{
+		{
+			Account:         Account{Username: "username1", ProfileUrl: "https://www.instagram.com/username1", Timestamp: time.Unix(1, 0)},
+			Follower:        true,
+			Following:       true,
+			Comments:        1,
+			Messages:        1,
+			LastInteraction: time.Unix(10, 0),
+		},
+		{
+			Account:   Account{Username: "username2", ProfileUrl: "https://www.instagram.com/username2", Timestamp: time.Unix(3, 0)},
+			Following: true,
+		},
+		{
+			Account:         Account{Username: "username3", ProfileUrl: "https://www.instagram.com/username3"},
+			Likes:           1,
+			Stories:         1,
+			LastInteraction: time.Unix(7, 0),
+		},
+	}, got)
+	assert.True(t, got[0].Mutual())
+	assert.False(t, got[1].Mutual())
+	assert.Equal(t, 2, got[0].Interactions())
+}
+
+const benchmarkEntries = 500000
+
+func generateAccounts(n int) []byte {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"string_list_data":[{"href":"https://www.instagram.com/username%d","value":"username%d","timestamp":%d}]}`, i, i, i)
+	}
+	b.WriteByte(']')
+	return b.Bytes()
+}
+
+func benchmarkLoader(b *testing.B, content []byte, load func(l *Loader) ([]Account, error)) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{instagram.PathFollowers}, nil)
+	f.On("Open", mock.Anything).Return(func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		l := NewLoaderWithFs(f)
+		b.StartTimer()
+		accounts, err := load(l)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(accounts) != benchmarkEntries {
+			b.Fatalf("got %d accounts, want %d", len(accounts), benchmarkEntries)
+		}
+	}
+}
+
+func BenchmarkLoader_Followers(b *testing.B) {
+	content := generateAccounts(benchmarkEntries)
+	benchmarkLoader(b, content, func(l *Loader) ([]Account, error) {
+		return l.Followers(context.Background())
+	})
+}
+
+func BenchmarkLoader_Following(b *testing.B) {
+	accounts := generateAccounts(benchmarkEntries)
+	content := append([]byte(`{"relationships_following":`), accounts...)
+	content = append(content, '}')
+	benchmarkLoader(b, content, func(l *Loader) ([]Account, error) {
+		return l.Following(context.Background())
+	})
+}
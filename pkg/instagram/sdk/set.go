@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"slices"
+	"strings"
+)
+
+type AccountSet struct {
+	keys     []string
+	accounts []Account
+	indexes  map[string]int
+}
+
+func NewAccountSet(accounts []Account) *AccountSet {
+	s := &AccountSet{
+		keys:     make([]string, 0, len(accounts)),
+		accounts: make([]Account, 0, len(accounts)),
+		indexes:  make(map[string]int, len(accounts)),
+	}
+	for i := range accounts {
+		s.Add(accounts[i])
+	}
+	return s
+}
+
+func NewUsernameSet(usernames []string) *AccountSet {
+	s := NewAccountSet(nil)
+	for i := range usernames {
+		s.Add(Account{Username: usernames[i]})
+	}
+	return s
+}
+
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+}
+
+func (s *AccountSet) Add(account Account) bool {
+	return s.add(NormalizeUsername(account.Username), account)
+}
+
+func (s *AccountSet) add(key string, account Account) bool {
+	if _, ok := s.indexes[key]; ok {
+		return false
+	}
+	s.indexes[key] = len(s.accounts)
+	s.keys = append(s.keys, key)
+	s.accounts = append(s.accounts, account)
+	return true
+}
+
+func (s *AccountSet) Contains(username string) bool {
+	_, ok := s.indexes[NormalizeUsername(username)]
+	return ok
+}
+
+func (s *AccountSet) Get(username string) (Account, bool) {
+	index, ok := s.indexes[NormalizeUsername(username)]
+	if !ok {
+		return Account{}, false
+	}
+	return s.accounts[index], true
+}
+
+func (s *AccountSet) Len() int {
+	return len(s.accounts)
+}
+
+func (s *AccountSet) Accounts() []Account {
+	return slices.Clone(s.accounts)
+}
+
+func (s *AccountSet) Each(visit func(username string, account Account)) {
+	for i, key := range s.keys {
+		visit(key, s.accounts[i])
+	}
+}
+
+func (s *AccountSet) Intersection(other *AccountSet) *AccountSet {
+	return s.filter(func(key string) bool {
+		_, ok := other.indexes[key]
+		return ok
+	})
+}
+
+func (s *AccountSet) Difference(other *AccountSet) *AccountSet {
+	return s.filter(func(key string) bool {
+		_, ok := other.indexes[key]
+		return !ok
+	})
+}
+
+func (s *AccountSet) SymmetricDifference(other *AccountSet) *AccountSet {
+	result := s.Difference(other)
+	for i, key := range other.keys {
+		if _, ok := s.indexes[key]; !ok {
+			result.add(key, other.accounts[i])
+		}
+	}
+	return result
+}
+
+func (s *AccountSet) filter(keep func(key string) bool) *AccountSet {
+	result := NewAccountSet(nil)
+	for i, key := range s.keys {
+		if keep(key) {
+			result.add(key, s.accounts[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func usernames(set *AccountSet) []string {
+	result := make([]string, 0, set.Len())
+	for _, account := range set.Accounts() {
+		result = append(result, account.Username)
+	}
+	return result
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{
+		{
+			name:     "lowercases username",
+			username: "UserName",
+			want:     "username",
+		},
+		{
+			name:     "trims whitespace and at sign",
+			username: " @user.name_1 ",
+			want:     "user.name_1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeUsername(tt.username))
+		})
+	}
+}
+
+func TestNewAccountSet(t *testing.T) {
+	set := NewAccountSet([]Account{
+		{Username: "username1", ProfileUrl: "first"},
+		{Username: "UserName1", ProfileUrl: "second"},
+		{Username: "username2"},
+	})
+	assert.Equal(t, 2, set.Len())
+	assert.True(t, set.Contains("@USERNAME1"))
+	assert.False(t, set.Contains("username3"))
+	account, ok := set.Get("username1")
+	assert.True(t, ok)
+	assert.Equal(t, "first", account.ProfileUrl)
+	_, ok = set.Get("username3")
+	assert.False(t, ok)
+	assert.False(t, set.Add(Account{Username: "username2"}))
+	assert.True(t, set.Add(Account{Username: "username3"}))
+	assert.Equal(t, []string{"username1", "username2", "username3"}, usernames(set))
+}
+
+func TestAccountSet_Each(t *testing.T) {
+	set := NewAccountSet([]Account{
+		{Username: "@UserName1", ProfileUrl: "first"},
+		{Username: "username2", ProfileUrl: "second"},
+	})
+	visited := make(map[string]string)
+	set.Each(func(username string, account Account) {
+		visited[username] = account.ProfileUrl
+	})
+	assert.Equal(t, map[string]string{"username1": "first", "username2": "second"}, visited)
+}
+
+func TestAccountSet_operations(t *testing.T) {
+	left := NewUsernameSet([]string{"username1", "UserName2", "username3"})
+	right := NewUsernameSet([]string{"username4", "username2", "USERNAME3"})
+	tests := []struct {
+		name string
+		got  *AccountSet
+		want []string
+	}{
+		{
+			name: "intersection",
+			got:  left.Intersection(right),
+			want: []string{"UserName2", "username3"},
+		},
+		{
+			name: "difference",
+			got:  left.Difference(right),
+			want: []string{"username1"},
+		},
+		{
+			name: "reverse difference",
+			got:  right.Difference(left),
+			want: []string{"username4"},
+		},
+		{
+			name: "symmetric difference",
+			got:  left.SymmetricDifference(right),
+			want: []string{"username1", "username4"},
+		},
+		{
+			name: "difference with empty set",
+			got:  left.Difference(NewAccountSet(nil)),
+			want: []string{"username1", "UserName2", "username3"},
+		},
+		{
+			name: "intersection with empty set",
+			got:  NewAccountSet(nil).Intersection(right),
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usernames(tt.got))
+		})
+	}
+}
+
+const benchmarkSetSize = 100000
+
+func benchmarkSets() (*AccountSet, *AccountSet) {
+	left := make([]Account, benchmarkSetSize)
+	right := make([]Account, benchmarkSetSize)
+	for i := 0; i < benchmarkSetSize; i++ {
+		left[i] = Account{Username: fmt.Sprintf("username%d", i)}
+		right[i] = Account{Username: fmt.Sprintf("username%d", i+benchmarkSetSize/2)}
+	}
+	return NewAccountSet(left), NewAccountSet(right)
+}
+
+func BenchmarkNewAccountSet(b *testing.B) {
+	accounts := make([]Account, benchmarkSetSize)
+	for i := range accounts {
+		accounts[i] = Account{Username: fmt.Sprintf("UserName%d", i)}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewAccountSet(accounts)
+	}
+}
+
+func BenchmarkAccountSet_Intersection(b *testing.B) {
+	left, right := benchmarkSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left.Intersection(right)
+	}
+}
+
+func BenchmarkAccountSet_Difference(b *testing.B) {
+	left, right := benchmarkSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left.Difference(right)
+	}
+}
+
+func BenchmarkAccountSet_SymmetricDifference(b *testing.B) {
+	left, right := benchmarkSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left.SymmetricDifference(right)
+	}
+}
@@ -0,0 +1,346 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	ellipsis            = "..."
+	keyCreationTime     = "creation_timestamp"
+	keyHref             = "href"
+	keyStringListData   = "string_list_data"
+	keyStringMapData    = "string_map_data"
+	keyTimestamp        = "timestamp"
+	keyTimestampMs      = "timestamp_ms"
+	keyUri              = "uri"
+	snippetContextBytes = 30
+)
+
+type Interface interface {
+	Search(opts *instagram.Options, query string, regex bool, ignoreCase bool) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+type source struct {
+	category string
+	pattern  string
+}
+
+var sources = []source{
+	{category: activity.CategoryComment, pattern: instagram.PathPostComments},
+	{category: activity.CategoryFollower, pattern: instagram.PathFollowers},
+	{category: activity.CategoryFollowing, pattern: instagram.PathFollowing},
+	{category: activity.CategoryLike, pattern: instagram.PathLikedPosts},
+	{category: activity.CategoryMessage, pattern: instagram.PathMessages},
+	{category: activity.CategoryPost, pattern: instagram.PathPosts},
+	{category: activity.CategorySearch, pattern: instagram.PathAccountSearches},
+	{category: activity.CategorySearch, pattern: instagram.PathKeywordSearches},
+	{category: activity.CategorySearch, pattern: instagram.PathTagSearches},
+	{category: activity.CategoryStory, pattern: instagram.PathStoryStickers},
+}
+
+func (h *handler) Search(opts *instagram.Options, query string, regex bool, ignoreCase bool) (*string, error) {
+	re, err := compileQuery(query, regex, ignoreCase)
+	if err != nil {
+		return nil, err
+	}
+	rl := &resultList{
+		results: make([]result, 0),
+	}
+	searched := 0
+	for _, s := range sources {
+		files, err := h.fileSystem.FindFiles(s.pattern)
+		if err != nil {
+			return nil, err
+		}
+		searched += len(files)
+		for _, file := range files {
+			results, err := h.searchPath(file, re, s.category)
+			if err != nil {
+				return nil, err
+			}
+			rl.results = append(rl.results, results...)
+		}
+	}
+	if searched == 0 {
+		return nil, h.missingSourcesError()
+	}
+	rl.Sort(opts.SortBy, opts.Order)
+	rl.Paginate(opts)
+	return instagram.Output(rl, opts)
+}
+
+func (h *handler) missingSourcesError() error {
+	errs := make([]error, 0, len(sources))
+	for _, s := range sources {
+		err := instagram.MissingDataError(h.fileSystem, s.pattern)
+		if errors.Is(err, instagram.ErrDataNotLoaded) {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func compileQuery(query string, regex bool, ignoreCase bool) (*regexp.Regexp, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query must not be empty")
+	}
+	if !regex {
+		query = regexp.QuoteMeta(query)
+	}
+	if ignoreCase {
+		query = "(?i)" + query
+	}
+	re, err := regexp.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return re, nil
+}
+
+func (h *handler) searchPath(name string, re *regexp.Regexp, category string) ([]result, error) {
+	file, err := h.fileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return searchFile(file, re, category, strings.TrimPrefix(name, instagram.PathData+"/"))
+}
+
+func searchFile(r io.Reader, re *regexp.Regexp, category string, file string) ([]result, error) {
+	results := make([]result, 0)
+	visit := func(value any) error {
+		walk(value, time.Time{}, func(text string, timestamp time.Time) {
+			loc := re.FindStringIndex(text)
+			if loc == nil || loc[0] == loc[1] {
+				return
+			}
+			r := result{
+				Category: category,
+				File:     file,
+				Snippet:  snippet(text, loc[0], loc[1]),
+			}
+			if !timestamp.IsZero() {
+				r.Timestamp = &instagram.Timestamp{Time: timestamp}
+			}
+			results = append(results, r)
+		})
+		return nil
+	}
+	dec := json.NewDecoder(r)
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('['):
+		err = instagram.DecodeElements(dec, visit)
+	case json.Delim('{'):
+		err = instagram.DecodeMembers(dec, func(string) error {
+			return decodeMember(dec, visit)
+		})
+	default:
+		err = fmt.Errorf("invalid json: expected array or object but got %v", token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func decodeMember(dec *json.Decoder, visit func(value any) error) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == json.Delim('[') {
+		return instagram.DecodeElements(dec, visit)
+	}
+	value, err := decodeValue(dec, token)
+	if err != nil {
+		return err
+	}
+	return visit(value)
+}
+
+func decodeValue(dec *json.Decoder, token json.Token) (any, error) {
+	switch token {
+	case json.Delim('{'):
+		object := make(map[string]any)
+		err := instagram.DecodeMembers(dec, func(key string) error {
+			next, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			object[key], err = decodeValue(dec, next)
+			return err
+		})
+		return object, err
+	case json.Delim('['):
+		array := make([]any, 0)
+		err := instagram.DecodeElements(dec, func(value any) error {
+			array = append(array, value)
+			return nil
+		})
+		return array, err
+	default:
+		return token, nil
+	}
+}
+
+func walk(node any, timestamp time.Time, visit func(text string, timestamp time.Time)) {
+	switch value := node.(type) {
+	case map[string]any:
+		if t := findTimestamp(value); !t.IsZero() {
+			timestamp = t
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if key == keyHref || key == keyUri {
+				continue
+			}
+			walk(value[key], timestamp, visit)
+		}
+	case []any:
+		for _, item := range value {
+			walk(item, timestamp, visit)
+		}
+	case string:
+		visit(value, timestamp)
+	}
+}
+
+func findTimestamp(node map[string]any) time.Time {
+	if seconds, ok := node[keyTimestamp].(float64); ok && seconds > 0 {
+		return time.Unix(int64(seconds), 0)
+	}
+	if ms, ok := node[keyTimestampMs].(float64); ok && ms > 0 {
+		return time.UnixMilli(int64(ms))
+	}
+	if seconds, ok := node[keyCreationTime].(float64); ok && seconds > 0 {
+		return time.Unix(int64(seconds), 0)
+	}
+	if list, ok := node[keyStringListData].([]any); ok && len(list) > 0 {
+		if first, ok := list[0].(map[string]any); ok {
+			return findTimestamp(first)
+		}
+	}
+	if values, ok := node[keyStringMapData].(map[string]any); ok {
+		for _, v := range values {
+			if m, ok := v.(map[string]any); ok {
+				if t := findTimestamp(m); !t.IsZero() {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func snippet(text string, start int, end int) string {
+	from, to := max(start-snippetContextBytes, 0), min(end+snippetContextBytes, len(text))
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+	s := strings.Join(strings.Fields(text[from:to]), " ")
+	if from > 0 {
+		s = ellipsis + s
+	}
+	if to < len(text) {
+		s += ellipsis
+	}
+	return s
+}
+
+type result struct {
+	Category  string               `json:"category" yaml:"category"`
+	File      string               `json:"file" yaml:"file"`
+	Timestamp *instagram.Timestamp `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Snippet   string               `json:"snippet" yaml:"snippet"`
+}
+
+type resultList struct {
+	results []result
+}
+
+func (rl *resultList) Data() any {
+	return rl.results
+}
+
+func (rl *resultList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderCategory,
+		instagram.TableHeaderFile,
+		instagram.TableHeaderTimestamp,
+		instagram.TableHeaderSnippet,
+	}
+}
+
+func (rl *resultList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range rl.results {
+		current := rl.results[i]
+		timestamp := ""
+		if current.Timestamp != nil {
+			timestamp = current.Timestamp.String()
+		}
+		rows = append(rows, table.Row{
+			current.Category,
+			current.File,
+			timestamp,
+			current.Snippet,
+		})
+	}
+	return rows
+}
+
+func (rl *resultList) Sort(_ string, order string) {
+	sort.SliceStable(rl.results, func(a, b int) bool {
+		return rl.results[a].time().Before(rl.results[b].time())
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(rl.results)
+	}
+}
+
+func (rl *resultList) Paginate(opts *instagram.Options) {
+	rl.results = instagram.Paginate(rl.results, opts)
+}
+
+func (r result) time() time.Time {
+	if r.Timestamp == nil {
+		return time.Time{}
+	}
+	return r.Timestamp.Time
+}
@@ -0,0 +1,295 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	messagesJson  = `{"participants":[{"name":"User One"},{"name":"You"}],"messages":[{"sender_name":"User One","timestamp_ms":1696161600000,"content":"Are you coming to the Golang meetup?"},{"sender_name":"You","timestamp_ms":1696165200000,"content":"see you there"}],"thread_path":"inbox/username1_123"}`
+	followingJson = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/golang","value":"golang","timestamp":1696075200}]}]}`
+)
+
+func Test_handler_Search(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	type args struct {
+		query      string
+		regex      bool
+		ignoreCase bool
+	}
+	tests := []struct {
+		name         string
+		args         args
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to search across all sources",
+			args: args{
+				query:      "golang",
+				ignoreCase: true,
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowing).Return([]string{instagram.PathFollowing}, nil)
+				f.fileSystem.On("FindFiles", instagram.PathMessages).Return([]string{"messages"}, nil)
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{}, nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.fileSystem.On("Open", "messages").Return(io.NopCloser(strings.NewReader(messagesJson)), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", len(sources))
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to compile query",
+			args: args{
+				query: "(",
+				regex: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to find files",
+			args: args{
+				query: "golang",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", mock.Anything).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to find any source",
+			args: args{
+				query: "golang",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{}, nil)
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", len(sources)+1)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to open file",
+			args: args{
+				query: "golang",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{"comments"}, nil)
+				f.fileSystem.On("Open", "comments").Return(nil, fmt.Errorf("fails to open file"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse file",
+			args: args{
+				query: "golang",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{"comments"}, nil)
+				f.fileSystem.On("Open", "comments").Return(io.NopCloser(strings.NewReader("invalid")), nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Search(instagram.NewEmptyOptions(), tt.args.query, tt.args.regex, tt.args.ignoreCase); (err != nil) != tt.wantErr {
+				t.Errorf("Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_compileQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		regex      bool
+		ignoreCase bool
+		text       string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:  "matches literal query",
+			query: "a.b",
+			text:  "a.b",
+			want:  true,
+		},
+		{
+			name:  "does not treat literal query as regex",
+			query: "a.b",
+			text:  "axb",
+			want:  false,
+		},
+		{
+			name:  "matches regex query",
+			query: "a.b",
+			regex: true,
+			text:  "axb",
+			want:  true,
+		},
+		{
+			name:       "matches case-insensitive query",
+			query:      "GoLang",
+			ignoreCase: true,
+			text:       "golang",
+			want:       true,
+		},
+		{
+			name:  "does not match different case",
+			query: "GoLang",
+			text:  "golang",
+			want:  false,
+		},
+		{
+			name:    "fails to compile empty query",
+			query:   "",
+			wantErr: true,
+		},
+		{
+			name:    "fails to compile invalid regex",
+			query:   "[",
+			regex:   true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := compileQuery(tt.query, tt.regex, tt.ignoreCase)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compileQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.want, re.MatchString(tt.text))
+			}
+		})
+	}
+}
+
+func Test_searchFile(t *testing.T) {
+	re, _ := compileQuery("you", false, true)
+	tests := []struct {
+		name    string
+		input   string
+		want    []result
+		wantErr bool
+	}{
+		{
+			name:  "succeeds to search object members",
+			input: messagesJson,
+			want: []result{
+				{Category: "message", File: "messages", Snippet: "You"},
+				{Category: "message", File: "messages", Timestamp: &instagram.Timestamp{Time: time.UnixMilli(1696161600000)}, Snippet: "Are you coming to the Golang meetup?"},
+				{Category: "message", File: "messages", Timestamp: &instagram.Timestamp{Time: time.UnixMilli(1696165200000)}, Snippet: "see you there"},
+				{Category: "message", File: "messages", Timestamp: &instagram.Timestamp{Time: time.UnixMilli(1696165200000)}, Snippet: "You"},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "succeeds to search array elements",
+			input: `[{"string_map_data":{"Comment":{"value":"thank you"},"Time":{"timestamp":1696161600}}}]`,
+			want: []result{
+				{Category: "message", File: "messages", Timestamp: &instagram.Timestamp{Time: time.Unix(1696161600, 0)}, Snippet: "thank you"},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "succeeds to search nested object members",
+			input: `{"image":{"uri":"you.jpg","caption":"you"},"title":"Your group"}`,
+			want: []result{
+				{Category: "message", File: "messages", Snippet: "you"},
+				{Category: "message", File: "messages", Snippet: "Your group"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "fails to search scalar",
+			input:   `"you"`,
+			wantErr: true,
+		},
+		{
+			name:    "fails to search truncated file",
+			input:   `{"messages":[{"content":"you"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := searchFile(strings.NewReader(tt.input), re, "message", "messages")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("searchFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_snippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		start int
+		end   int
+		want  string
+	}{
+		{
+			name:  "returns whole short text",
+			text:  "hello golang",
+			start: 6,
+			end:   12,
+			want:  "hello golang",
+		},
+		{
+			name:  "trims long text around the match",
+			text:  "the quick brown fox jumps over the lazy dog while the golang gopher watches from a distance and takes notes",
+			start: 54,
+			end:   60,
+			want:  "...s over the lazy dog while the golang gopher watches from a distanc...",
+		},
+		{
+			name:  "collapses whitespace",
+			text:  "hello\n\ngolang",
+			start: 7,
+			end:   13,
+			want:  "hello golang",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, snippet(tt.text, tt.start, tt.end))
+		})
+	}
+}
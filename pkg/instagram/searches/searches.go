@@ -0,0 +1,323 @@
+package searches
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	typeAccount = "account"
+	typeKeyword = "keyword"
+	typeTag     = "tag"
+)
+
+type Interface interface {
+	Accounts(opts *instagram.Options, notFollowing bool) (*string, error)
+	History(opts *instagram.Options) (*string, error)
+	Top(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Accounts(opts *instagram.Options, notFollowing bool) (*string, error) {
+	searches, err := h.loadSearches()
+	if err != nil {
+		return nil, err
+	}
+	following, err := followdata.LoadFollowing(h.fileSystem)
+	if err != nil {
+		return nil, err
+	}
+	rl := newRankingList(searches, typeAccount)
+	rl.hydrateFollowing(following)
+	if notFollowing {
+		rl.rankings = slices.DeleteFunc(rl.rankings, func(r ranking) bool {
+			return *r.Following
+		})
+	}
+	rl.Sort(opts.SortBy, opts.Order)
+	rl.Paginate(opts)
+	return instagram.Output(rl, opts)
+}
+
+func (h *handler) History(opts *instagram.Options) (*string, error) {
+	searches, err := h.loadSearches()
+	if err != nil {
+		return nil, err
+	}
+	sl := &searchList{
+		searches: searches,
+	}
+	sl.Sort(opts.SortBy, opts.Order)
+	sl.Paginate(opts)
+	return instagram.Output(sl, opts)
+}
+
+func (h *handler) Top(opts *instagram.Options) (*string, error) {
+	searches, err := h.loadSearches()
+	if err != nil {
+		return nil, err
+	}
+	rl := newRankingList(searches, typeAccount, typeTag)
+	rl.Sort(opts.SortBy, opts.Order)
+	rl.Paginate(opts)
+	return instagram.Output(rl, opts)
+}
+
+func LoadEvents(fileSystem filesystem.Fs) ([]activity.Event, error) {
+	h := &handler{
+		fileSystem: fileSystem,
+	}
+	searches, err := h.loadSearches()
+	if err != nil {
+		return nil, err
+	}
+	events := make([]activity.Event, 0, len(searches))
+	for _, s := range searches {
+		event := activity.Event{
+			Category:  activity.CategorySearch,
+			Text:      s.Query,
+			Timestamp: s.Timestamp.Time,
+		}
+		if s.Type == typeAccount {
+			event.Username = s.Query
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+type searchFile struct {
+	path       string
+	searchType string
+}
+
+func (h *handler) loadSearches() ([]search, error) {
+	files := []searchFile{
+		{path: instagram.PathAccountSearches, searchType: typeAccount},
+		{path: instagram.PathTagSearches, searchType: typeTag},
+		{path: instagram.PathKeywordSearches, searchType: typeKeyword},
+	}
+	searches := make([]search, 0)
+	found := false
+	for _, file := range files {
+		data, err := h.fileSystem.ReadFile(file.path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		parsed, err := parseSearches(data, file.searchType)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, parsed...)
+	}
+	if !found {
+		return nil, instagram.MissingDataError(h.fileSystem, instagram.PathAccountSearches)
+	}
+	return searches, nil
+}
+
+func parseSearches(data []byte, searchType string) ([]search, error) {
+	events, err := activity.ParseSearches(data)
+	if err != nil {
+		return nil, err
+	}
+	searches := make([]search, 0, len(events))
+	for _, event := range events {
+		query := event.Text
+		if searchType == typeTag {
+			query = strings.TrimPrefix(query, "#")
+		}
+		searches = append(searches, search{
+			Type:      searchType,
+			Query:     query,
+			Timestamp: instagram.NewTimestamp(event.Timestamp.Unix()),
+		})
+	}
+	return searches, nil
+}
+
+type search struct {
+	Type      string               `json:"type" yaml:"type"`
+	Query     string               `json:"query" yaml:"query"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type searchList struct {
+	searches []search
+}
+
+func (sl *searchList) Data() any {
+	return sl.searches
+}
+
+func (sl *searchList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderType,
+		instagram.TableHeaderQuery,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (sl *searchList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range sl.searches {
+		current := sl.searches[i]
+		rows = append(rows, table.Row{
+			current.Type,
+			current.Query,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (sl *searchList) Sort(field string, order string) {
+	sort.SliceStable(sl.searches, func(a, b int) bool {
+		searchOne := sl.searches[a]
+		searchTwo := sl.searches[b]
+		switch field {
+		case instagram.FieldName:
+			return searchOne.Query < searchTwo.Query
+		default:
+			return searchOne.Timestamp.Time.Before(searchTwo.Timestamp.Time)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(sl.searches)
+	}
+}
+
+func (sl *searchList) Paginate(opts *instagram.Options) {
+	sl.searches = instagram.Paginate(sl.searches, opts)
+}
+
+type ranking struct {
+	Type         string               `json:"type" yaml:"type"`
+	Query        string               `json:"query" yaml:"query"`
+	Searches     int                  `json:"searches" yaml:"searches"`
+	LastSearched *instagram.Timestamp `json:"lastSearched" yaml:"lastSearched"`
+	Following    *bool                `json:"following,omitempty" yaml:"following,omitempty"`
+}
+
+type rankingList struct {
+	rankings      []ranking
+	showFollowing bool
+}
+
+func newRankingList(searches []search, searchTypes ...string) *rankingList {
+	rl := &rankingList{
+		rankings: make([]ranking, 0),
+	}
+	indexes := make(map[string]int)
+	for i := range searches {
+		current := searches[i]
+		if !slices.Contains(searchTypes, current.Type) {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", current.Type, strings.ToLower(current.Query))
+		index, ok := indexes[key]
+		if !ok {
+			index = len(rl.rankings)
+			indexes[key] = index
+			rl.rankings = append(rl.rankings, ranking{
+				Type:         current.Type,
+				Query:        current.Query,
+				LastSearched: current.Timestamp,
+			})
+		}
+		rl.rankings[index].Searches++
+		if current.Timestamp.After(rl.rankings[index].LastSearched.Time) {
+			rl.rankings[index].LastSearched = current.Timestamp
+		}
+	}
+	return rl
+}
+
+func (rl *rankingList) hydrateFollowing(following *sdk.AccountSet) {
+	for i := range rl.rankings {
+		isFollowing := following.Contains(rl.rankings[i].Query)
+		rl.rankings[i].Following = &isFollowing
+	}
+	rl.showFollowing = true
+}
+
+func (rl *rankingList) Data() any {
+	return rl.rankings
+}
+
+func (rl *rankingList) TableHeader() table.Row {
+	header := table.Row{
+		instagram.TableHeaderType,
+		instagram.TableHeaderQuery,
+		instagram.TableHeaderSearches,
+		instagram.TableHeaderLastSearched,
+	}
+	if rl.showFollowing {
+		header = append(header, instagram.TableHeaderFollowing)
+	}
+	return header
+}
+
+func (rl *rankingList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range rl.rankings {
+		current := rl.rankings[i]
+		row := table.Row{
+			current.Type,
+			current.Query,
+			current.Searches,
+			current.LastSearched,
+		}
+		if rl.showFollowing {
+			row = append(row, *current.Following)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (rl *rankingList) Sort(field string, order string) {
+	sort.SliceStable(rl.rankings, func(a, b int) bool {
+		rankingOne := rl.rankings[a]
+		rankingTwo := rl.rankings[b]
+		switch field {
+		case instagram.FieldName:
+			return rankingOne.Query < rankingTwo.Query
+		case instagram.FieldTimestamp:
+			return rankingOne.LastSearched.Time.Before(rankingTwo.LastSearched.Time)
+		default:
+			return rankingOne.Searches < rankingTwo.Searches
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(rl.rankings)
+	}
+}
+
+func (rl *rankingList) Paginate(opts *instagram.Options) {
+	rl.rankings = instagram.Paginate(rl.rankings, opts)
+}
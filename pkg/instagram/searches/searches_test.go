@@ -0,0 +1,286 @@
+package searches
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	accountSearchesJson = `{"searches_user":[{"title":"","string_map_data":{"Search":{"value":"username1"},"Time":{"timestamp":1696161600}}},{"title":"","string_map_data":{"Search":{"value":"username2"},"Time":{"timestamp":1696248000}}},{"title":"","string_map_data":{"Search":{"value":"Username1"},"Time":{"timestamp":1696334400}}}]}`
+	tagSearchesJson     = `{"searches_hashtag":[{"title":"","string_map_data":{"Search":{"value":"#golang"},"Time":{"timestamp":1696161600}}}]}`
+	keywordSearchesJson = `{"searches_keyword":[{"title":"","string_list_data":[{"value":"coffee","timestamp":1696161600}]}]}`
+	followingJson       = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":0}]}]}`
+)
+
+func expectSearches(f *filesystem.MockFs) {
+	f.On("ReadFile", instagram.PathAccountSearches).Return([]byte(accountSearchesJson), nil)
+	f.On("ReadFile", instagram.PathTagSearches).Return([]byte(tagSearchesJson), nil)
+	f.On("ReadFile", instagram.PathKeywordSearches).Return(nil, fs.ErrNotExist)
+}
+
+func Test_handler_History(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output search history",
+			expectations: func(f *fields) {
+				expectSearches(f.fileSystem)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAccountSearches).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to find searches category",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse searches",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAccountSearches).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.History(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("History() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Top(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output top searches",
+			expectations: func(f *fields) {
+				expectSearches(f.fileSystem)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to load searches",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAccountSearches).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Top(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Top() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Accounts(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output searched accounts",
+			expectations: func(f *fields) {
+				expectSearches(f.fileSystem)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to load searches",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathAccountSearches).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to read following",
+			expectations: func(f *fields) {
+				expectSearches(f.fileSystem)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse following",
+			expectations: func(f *fields) {
+				expectSearches(f.fileSystem)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader("")), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 3)
+				f.fileSystem.AssertNumberOfCalls(t, "Open", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Accounts(instagram.NewEmptyOptions(), true); (err != nil) != tt.wantErr {
+				t.Errorf("Accounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("ReadFile", instagram.PathAccountSearches).Return([]byte(accountSearchesJson), nil)
+	f.On("ReadFile", instagram.PathTagSearches).Return([]byte(tagSearchesJson), nil)
+	f.On("ReadFile", instagram.PathKeywordSearches).Return(nil, fs.ErrNotExist)
+	events, err := LoadEvents(f)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(events))
+	assert.Equal(t, activity.Event{
+		Category:  activity.CategorySearch,
+		Username:  "username1",
+		Text:      "username1",
+		Timestamp: time.Unix(1696161600, 0),
+	}, events[0])
+	assert.Equal(t, activity.Event{
+		Category:  activity.CategorySearch,
+		Text:      "golang",
+		Timestamp: time.Unix(1696161600, 0),
+	}, events[3])
+	f = &filesystem.MockFs{}
+	f.On("ReadFile", instagram.PathAccountSearches).Return(nil, fmt.Errorf("fails to read file"))
+	_, err = LoadEvents(f)
+	assert.Error(t, err)
+}
+
+func Test_parseSearches(t *testing.T) {
+	tags, err := parseSearches([]byte(tagSearchesJson), typeTag)
+	assert.NoError(t, err)
+	assert.Equal(t, "golang", tags[0].Query)
+	keywords, err := parseSearches([]byte(keywordSearchesJson), typeKeyword)
+	assert.NoError(t, err)
+	assert.Equal(t, "coffee", keywords[0].Query)
+	assert.Equal(t, int64(1696161600), keywords[0].Timestamp.Unix())
+}
+
+func Test_newRankingList(t *testing.T) {
+	accounts, err := parseSearches([]byte(accountSearchesJson), typeAccount)
+	assert.NoError(t, err)
+	tags, err := parseSearches([]byte(tagSearchesJson), typeTag)
+	assert.NoError(t, err)
+	rl := newRankingList(append(accounts, tags...), typeAccount)
+	assert.Equal(t, 2, len(rl.rankings))
+	rl.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, "username1", rl.rankings[0].Query)
+	assert.Equal(t, 2, rl.rankings[0].Searches)
+	assert.Equal(t, int64(1696334400), rl.rankings[0].LastSearched.Unix())
+	rl.hydrateFollowing(sdk.NewUsernameSet([]string{"username1"}))
+	assert.True(t, *rl.rankings[0].Following)
+	assert.False(t, *rl.rankings[1].Following)
+	assert.Equal(t, 5, len(rl.TableRows()[0]))
+}
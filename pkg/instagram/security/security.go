@@ -0,0 +1,364 @@
+package security
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	eventLogin          = "login"
+	eventLogout         = "logout"
+	eventPasswordChange = "password change"
+	eventSignup         = "signup"
+	keyDevice           = "Device"
+	keyIpAddress        = "IP Address"
+	keyTime             = "Time"
+	keyUserAgent        = "User Agent"
+	reasonNewDevice     = "new device"
+	reasonNewIpRange    = "new ip range"
+	reasonPassword      = "password change"
+	reasonUnusualHour   = "unusual hour"
+)
+
+type Interface interface {
+	Audit(opts *instagram.Options, unusualHours string) (*string, error)
+	Logins(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Audit(opts *instagram.Options, unusualHours string) (*string, error) {
+	hours, err := parseHourRange(unusualHours)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := h.loadSessions(true)
+	if err != nil {
+		return nil, err
+	}
+	fl := newFindingList(sessions, hours)
+	fl.Sort(opts.SortBy, opts.Order)
+	fl.Paginate(opts)
+	return instagram.Output(fl, opts)
+}
+
+func (h *handler) Logins(opts *instagram.Options) (*string, error) {
+	sessions, err := h.loadSessions(false)
+	if err != nil {
+		return nil, err
+	}
+	sl := &sessionList{
+		sessions: sessions,
+	}
+	sl.Sort(opts.SortBy, opts.Order)
+	sl.Paginate(opts)
+	return instagram.Output(sl, opts)
+}
+
+func LoadLogins(fileSystem filesystem.Fs) ([]activity.Event, error) {
+	h := &handler{
+		fileSystem: fileSystem,
+	}
+	sessions, err := h.loadSessions(false)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]activity.Event, 0, len(sessions))
+	for _, s := range sessions {
+		if s.Event != eventLogin {
+			continue
+		}
+		events = append(events, activity.Event{
+			Category:  activity.CategoryLogin,
+			Text:      s.IpAddress,
+			Timestamp: s.Timestamp.Time,
+		})
+	}
+	return events, nil
+}
+
+type activityFile struct {
+	path     string
+	event    string
+	required bool
+}
+
+func (h *handler) loadSessions(includeAccountChanges bool) ([]session, error) {
+	files := []activityFile{
+		{path: instagram.PathLoginActivity, event: eventLogin, required: true},
+		{path: instagram.PathLogoutActivity, event: eventLogout},
+	}
+	if includeAccountChanges {
+		files = append(files,
+			activityFile{path: instagram.PathSignupInformation, event: eventSignup},
+			activityFile{path: instagram.PathPasswordChangeActivity, event: eventPasswordChange},
+		)
+	}
+	sessions := make([]session, 0)
+	for _, file := range files {
+		data, err := h.fileSystem.ReadFile(file.path)
+		if err != nil {
+			if !file.required && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, instagram.WrapNotExist(h.fileSystem, file.path, err)
+		}
+		parsed, err := parseSessions(data, file.event)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, parsed...)
+	}
+	return sessions, nil
+}
+
+func parseSessions(data []byte, event string) ([]session, error) {
+	jsonData := make(map[string][]instagram.Entry)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	sessions := make([]session, 0)
+	for _, entries := range jsonData {
+		for _, entry := range entries {
+			userAgent := entry.StringMapData[keyUserAgent].Value
+			if userAgent == "" {
+				userAgent = entry.StringMapData[keyDevice].Value
+			}
+			timestamp, err := parseTime(entry)
+			if err != nil {
+				return nil, err
+			}
+			sessions = append(sessions, session{
+				Event:     event,
+				IpAddress: entry.StringMapData[keyIpAddress].Value,
+				UserAgent: userAgent,
+				Timestamp: timestamp,
+			})
+		}
+	}
+	return sessions, nil
+}
+
+func parseTime(entry instagram.Entry) (*instagram.Timestamp, error) {
+	if unix := entry.StringMapData[keyTime].Timestamp; unix != 0 {
+		return instagram.NewTimestamp(unix), nil
+	}
+	t, err := time.Parse(time.RFC3339, entry.Title)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session time: %q", entry.Title)
+	}
+	return instagram.NewTimestamp(t.Unix()), nil
+}
+
+type hourRange struct {
+	start int
+	end   int
+}
+
+func parseHourRange(value string) (*hourRange, error) {
+	bounds := strings.Split(value, "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid hour range: %s", value)
+	}
+	start, err := strconv.Atoi(bounds[0])
+	if err != nil || start < 0 || start > 23 {
+		return nil, fmt.Errorf("invalid hour range: %s", value)
+	}
+	end, err := strconv.Atoi(bounds[1])
+	if err != nil || end < 0 || end > 23 {
+		return nil, fmt.Errorf("invalid hour range: %s", value)
+	}
+	return &hourRange{
+		start: start,
+		end:   end,
+	}, nil
+}
+
+func (hr *hourRange) contains(hour int) bool {
+	if hr.start <= hr.end {
+		return hour >= hr.start && hour <= hr.end
+	}
+	return hour >= hr.start || hour <= hr.end
+}
+
+func ipRange(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return address
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return fmt.Sprintf("%s/24", ipv4.Mask(net.CIDRMask(24, 32)))
+	}
+	return fmt.Sprintf("%s/48", ip.Mask(net.CIDRMask(48, 128)))
+}
+
+type session struct {
+	Event     string               `json:"event" yaml:"event"`
+	IpAddress string               `json:"ipAddress" yaml:"ipAddress"`
+	UserAgent string               `json:"userAgent" yaml:"userAgent"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type sessionList struct {
+	sessions []session
+}
+
+func (sl *sessionList) Data() any {
+	return sl.sessions
+}
+
+func (sl *sessionList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderEvent,
+		instagram.TableHeaderIpAddress,
+		instagram.TableHeaderUserAgent,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (sl *sessionList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range sl.sessions {
+		current := sl.sessions[i]
+		rows = append(rows, table.Row{
+			current.Event,
+			current.IpAddress,
+			current.UserAgent,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (sl *sessionList) Sort(_ string, order string) {
+	sort.SliceStable(sl.sessions, func(a, b int) bool {
+		return sl.sessions[a].Timestamp.Time.Before(sl.sessions[b].Timestamp.Time)
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(sl.sessions)
+	}
+}
+
+func (sl *sessionList) Paginate(opts *instagram.Options) {
+	sl.sessions = instagram.Paginate(sl.sessions, opts)
+}
+
+type finding struct {
+	Event     string               `json:"event" yaml:"event"`
+	IpAddress string               `json:"ipAddress" yaml:"ipAddress"`
+	UserAgent string               `json:"userAgent" yaml:"userAgent"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+	Reasons   []string             `json:"reasons" yaml:"reasons"`
+}
+
+type findingList struct {
+	findings []finding
+}
+
+func newFindingList(sessions []session, unusualHours *hourRange) *findingList {
+	fl := &findingList{
+		findings: make([]finding, 0),
+	}
+	chronological := slices.Clone(sessions)
+	sort.SliceStable(chronological, func(a, b int) bool {
+		return chronological[a].Timestamp.Time.Before(chronological[b].Timestamp.Time)
+	})
+	knownDevices := make(map[string]bool)
+	knownIpRanges := make(map[string]bool)
+	for _, current := range chronological {
+		var reasons []string
+		switch current.Event {
+		case eventLogin, eventSignup:
+			if len(knownDevices) > 0 && current.UserAgent != "" && !knownDevices[current.UserAgent] {
+				reasons = append(reasons, reasonNewDevice)
+			}
+			if len(knownIpRanges) > 0 && current.IpAddress != "" && !knownIpRanges[ipRange(current.IpAddress)] {
+				reasons = append(reasons, reasonNewIpRange)
+			}
+			if current.Event == eventLogin && unusualHours.contains(current.Timestamp.Hour()) {
+				reasons = append(reasons, reasonUnusualHour)
+			}
+			if current.UserAgent != "" {
+				knownDevices[current.UserAgent] = true
+			}
+			if current.IpAddress != "" {
+				knownIpRanges[ipRange(current.IpAddress)] = true
+			}
+		case eventPasswordChange:
+			reasons = append(reasons, reasonPassword)
+		}
+		if len(reasons) > 0 {
+			fl.findings = append(fl.findings, finding{
+				Event:     current.Event,
+				IpAddress: current.IpAddress,
+				UserAgent: current.UserAgent,
+				Timestamp: current.Timestamp,
+				Reasons:   reasons,
+			})
+		}
+	}
+	return fl
+}
+
+func (fl *findingList) Data() any {
+	return fl.findings
+}
+
+func (fl *findingList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderEvent,
+		instagram.TableHeaderIpAddress,
+		instagram.TableHeaderUserAgent,
+		instagram.TableHeaderTimestamp,
+		instagram.TableHeaderReasons,
+	}
+}
+
+func (fl *findingList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range fl.findings {
+		current := fl.findings[i]
+		rows = append(rows, table.Row{
+			current.Event,
+			current.IpAddress,
+			current.UserAgent,
+			current.Timestamp,
+			strings.Join(current.Reasons, ", "),
+		})
+	}
+	return rows
+}
+
+func (fl *findingList) Sort(_ string, order string) {
+	sort.SliceStable(fl.findings, func(a, b int) bool {
+		return fl.findings[a].Timestamp.Time.Before(fl.findings[b].Timestamp.Time)
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(fl.findings)
+	}
+}
+
+func (fl *findingList) Paginate(opts *instagram.Options) {
+	fl.findings = instagram.Paginate(fl.findings, opts)
+}
@@ -0,0 +1,313 @@
+package security
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	loginsJson          = `{"account_history_login_history":[{"title":"2023-10-01T12:00:00+00:00","string_map_data":{"IP Address":{"value":"192.168.1.10"},"Time":{"timestamp":1696161600},"User Agent":{"value":"agent1"}}}]}`
+	logoutsJson         = `{"account_history_logout_history":[{"title":"2023-10-01T14:00:00+00:00","string_map_data":{"IP Address":{"value":"192.168.1.10"},"User Agent":{"value":"agent1"}}}]}`
+	signupJson          = `{"account_history_registration_info":[{"title":"","string_map_data":{"IP Address":{"value":"192.168.1.20"},"Time":{"timestamp":1577836800},"Device":{"value":"agent1"}}}]}`
+	passwordChangesJson = `{"account_history_password_change_history":[{"title":"","string_map_data":{"Time":{"timestamp":1696165200}}}]}`
+)
+
+func Test_handler_Logins(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output logins",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return([]byte(loginsJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLogoutActivity).Return([]byte(logoutsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output logins when optional file is missing",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return([]byte(loginsJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLogoutActivity).Return(nil, fs.ErrNotExist)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read required file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to read optional file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return([]byte(loginsJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLogoutActivity).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse sessions",
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return([]byte(""), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Logins(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Logins() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Audit(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	type args struct {
+		unusualHours string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output audit",
+			args: args{
+				unusualHours: "0-5",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return([]byte(loginsJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLogoutActivity).Return([]byte(logoutsJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathSignupInformation).Return([]byte(signupJson), nil)
+				f.fileSystem.On("ReadFile", instagram.PathPasswordChangeActivity).Return([]byte(passwordChangesJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 4)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse unusual hours",
+			args: args{
+				unusualHours: "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load sessions",
+			args: args{
+				unusualHours: "0-5",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLoginActivity).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Audit(instagram.NewEmptyOptions(), tt.args.unusualHours); (err != nil) != tt.wantErr {
+				t.Errorf("Audit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func TestLoadLogins(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("ReadFile", instagram.PathLoginActivity).Return([]byte(loginsJson), nil)
+	f.On("ReadFile", instagram.PathLogoutActivity).Return([]byte(logoutsJson), nil)
+	events, err := LoadLogins(f)
+	assert.NoError(t, err)
+	assert.Equal(t, []activity.Event{
+		{
+			Category:  activity.CategoryLogin,
+			Text:      "192.168.1.10",
+			Timestamp: time.Unix(1696161600, 0),
+		},
+	}, events)
+	f = &filesystem.MockFs{}
+	f.On("ReadFile", instagram.PathLoginActivity).Return(nil, fs.ErrNotExist)
+	f.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+	_, err = LoadLogins(f)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.ErrorIs(t, err, instagram.ErrCategoryMissing)
+}
+
+func Test_newFindingList(t *testing.T) {
+	base := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.Local)
+	sessions := []session{
+		{Event: eventLogin, IpAddress: "10.0.0.1", UserAgent: "agent1", Timestamp: &instagram.Timestamp{Time: base}},
+		{Event: eventLogout, IpAddress: "10.0.0.1", UserAgent: "agent1", Timestamp: &instagram.Timestamp{Time: base.Add(time.Hour)}},
+		{Event: eventLogin, IpAddress: "10.0.0.2", UserAgent: "agent1", Timestamp: &instagram.Timestamp{Time: base.Add(2 * time.Hour)}},
+		{Event: eventLogin, IpAddress: "10.0.1.1", UserAgent: "agent2", Timestamp: &instagram.Timestamp{Time: base.Add(15 * time.Hour)}},
+		{Event: eventPasswordChange, Timestamp: &instagram.Timestamp{Time: base.Add(16 * time.Hour)}},
+	}
+	hours, err := parseHourRange("22-5")
+	assert.NoError(t, err)
+	fl := newFindingList(sessions, hours)
+	assert.Equal(t, 2, len(fl.findings))
+	assert.Equal(t, []string{reasonNewDevice, reasonNewIpRange, reasonUnusualHour}, fl.findings[0].Reasons)
+	assert.Equal(t, []string{reasonPassword}, fl.findings[1].Reasons)
+	sessions = []session{
+		{Event: eventLogin, IpAddress: "10.0.0.1", Timestamp: &instagram.Timestamp{Time: base}},
+		{Event: eventLogin, IpAddress: "10.0.0.2", UserAgent: "agent1", Timestamp: &instagram.Timestamp{Time: base.Add(time.Hour)}},
+	}
+	fl = newFindingList(sessions, hours)
+	assert.Equal(t, 0, len(fl.findings))
+}
+
+func Test_parseTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   instagram.Entry
+		want    *instagram.Timestamp
+		wantErr bool
+	}{
+		{
+			name: "succeeds to parse time from timestamp",
+			entry: instagram.Entry{
+				StringMapData: map[string]instagram.StringData{keyTime: {Timestamp: 1696161600}},
+			},
+			want:    instagram.NewTimestamp(1696161600),
+			wantErr: false,
+		},
+		{
+			name: "succeeds to parse time from title",
+			entry: instagram.Entry{
+				Title: "2023-10-01T12:00:00+00:00",
+			},
+			want:    instagram.NewTimestamp(1696161600),
+			wantErr: false,
+		},
+		{
+			name: "fails to parse time",
+			entry: instagram.Entry{
+				Title: "invalid",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseHourRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *hourRange
+		wantErr bool
+	}{
+		{
+			name:    "succeeds to parse hour range",
+			value:   "0-5",
+			want:    &hourRange{start: 0, end: 5},
+			wantErr: false,
+		},
+		{
+			name:    "fails to parse missing bound",
+			value:   "5",
+			wantErr: true,
+		},
+		{
+			name:    "fails to parse invalid start",
+			value:   "x-5",
+			wantErr: true,
+		},
+		{
+			name:    "fails to parse out of range end",
+			value:   "0-24",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHourRange(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseHourRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ipRange(t *testing.T) {
+	assert.Equal(t, "192.168.1.0/24", ipRange("192.168.1.10"))
+	assert.Equal(t, "2001:db8:1::/48", ipRange("2001:db8:1:2::1"))
+	assert.Equal(t, "invalid", ipRange("invalid"))
+}
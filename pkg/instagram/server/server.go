@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+)
+
+const (
+	PathFollowers     = "/followers"
+	PathFollowing     = "/following"
+	PathHealth        = "/health"
+	PathUnfollowers   = "/unfollowers"
+	paramLimit        = instagram.FlagLimit
+	paramOffset       = instagram.FlagOffset
+	paramOrder        = instagram.FlagOrder
+	paramOutput       = instagram.FlagOutput
+	paramPage         = instagram.FlagPage
+	paramPageSize     = instagram.FlagPageSize
+	paramSort         = "sort"
+	paramSortBy       = instagram.FlagSortBy
+	headerAccept      = "Accept"
+	headerContentType = "Content-Type"
+	shutdownTimeout   = 5 * time.Second
+)
+
+var errNotAcceptable = errors.New("accept header must allow json, yaml or csv")
+
+var mediaTypes = map[string]string{
+	instagram.OutputCsv:  "text/csv",
+	instagram.OutputJson: "application/json",
+	instagram.OutputYaml: "application/yaml",
+}
+
+var acceptedMediaTypes = map[string]string{
+	"*/*":                instagram.OutputJson,
+	"application/*":      instagram.OutputJson,
+	"application/json":   instagram.OutputJson,
+	"application/x-yaml": instagram.OutputYaml,
+	"application/yaml":   instagram.OutputYaml,
+	"text/*":             instagram.OutputCsv,
+	"text/csv":           instagram.OutputCsv,
+	"text/yaml":          instagram.OutputYaml,
+}
+
+type handler struct {
+	mux        *http.ServeMux
+	followData followdata.Interface
+}
+
+func NewHandler(fileSystem filesystem.Fs) http.Handler {
+	return newHandler(followdata.NewHandler(fileSystem))
+}
+
+func newHandler(followData followdata.Interface) http.Handler {
+	h := &handler{
+		mux:        http.NewServeMux(),
+		followData: followData,
+	}
+	h.mux.HandleFunc(PathHealth, h.health)
+	h.mux.HandleFunc(PathFollowers, h.list(followdata.Interface.Followers))
+	h.mux.HandleFunc(PathFollowing, h.list(followdata.Interface.Following))
+	h.mux.HandleFunc(PathUnfollowers, h.list(followdata.Interface.Unfollowers))
+	return h
+}
+
+func ListenAndServe(ctx context.Context, addr string, h http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *handler) health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(headerContentType, mediaTypes[instagram.OutputJson])
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *handler) list(retrieve func(followdata.Interface, context.Context, *instagram.Options) (*string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseOptions(r)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errNotAcceptable) {
+				status = http.StatusNotAcceptable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		body, err := retrieve(h.followData, r.Context(), opts)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, fs.ErrNotExist) || errors.Is(err, instagram.ErrDataNotLoaded) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Header().Set(headerContentType, mediaTypes[opts.Output])
+		_, _ = w.Write([]byte(*body))
+	}
+}
+
+func parseOptions(r *http.Request) (*instagram.Options, error) {
+	query := r.URL.Query()
+	opts := &instagram.Options{
+		Limit:  instagram.Unlimited,
+		Order:  instagram.OrderDesc,
+		Output: negotiateOutput(r.Header.Get(headerAccept)),
+		SortBy: instagram.FieldTimestamp,
+	}
+	params := map[string]*int{
+		paramLimit:    &opts.Limit,
+		paramOffset:   &opts.Offset,
+		paramPage:     &opts.Page,
+		paramPageSize: &opts.PageSize,
+	}
+	for name, target := range params {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", name, value)
+		}
+		*target = number
+	}
+	if value := query.Get(paramOrder); value != "" {
+		opts.Order = value
+	}
+	for _, name := range []string{paramSort, paramSortBy} {
+		if value := query.Get(name); value != "" {
+			opts.SortBy = value
+		}
+	}
+	if value := query.Get(paramOutput); value != "" {
+		if _, ok := mediaTypes[value]; !ok {
+			return nil, fmt.Errorf("invalid output format: %s", value)
+		}
+		opts.Output = value
+	}
+	if opts.Output == "" {
+		return nil, errNotAcceptable
+	}
+	if err := opts.Validate(instagram.FieldTimestamp, instagram.FieldUsername); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func negotiateOutput(accept string) string {
+	if accept == "" {
+		return instagram.OutputJson
+	}
+	output, quality := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		candidate, ok := acceptedMediaTypes[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q > quality {
+			output, quality = candidate, q
+		}
+	}
+	return output
+}
@@ -0,0 +1,312 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const followersJson = `[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1696161600}]}]`
+
+type stubFollowData struct {
+	opts *instagram.Options
+	err  error
+}
+
+func (s *stubFollowData) Followers(_ context.Context, opts *instagram.Options) (*string, error) {
+	return s.respond(opts)
+}
+
+func (s *stubFollowData) Following(_ context.Context, opts *instagram.Options) (*string, error) {
+	return s.respond(opts)
+}
+
+func (s *stubFollowData) Inactive(_ context.Context, opts *instagram.Options, _ string) (*string, error) {
+	return s.respond(opts)
+}
+
+func (s *stubFollowData) Relationships(_ context.Context, opts *instagram.Options, _ string) (*string, error) {
+	return s.respond(opts)
+}
+
+func (s *stubFollowData) Unfollowers(_ context.Context, opts *instagram.Options) (*string, error) {
+	return s.respond(opts)
+}
+
+func (s *stubFollowData) respond(opts *instagram.Options) (*string, error) {
+	s.opts = opts
+	if s.err != nil {
+		return nil, s.err
+	}
+	body := opts.Output
+	return &body, nil
+}
+
+func Test_handler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		accept          string
+		err             error
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+		wantOpts        *instagram.Options
+	}{
+		{
+			name:            "succeeds to report health",
+			method:          http.MethodGet,
+			target:          PathHealth,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"status":"ok"}`,
+		},
+		{
+			name:            "succeeds to retrieve followers with defaults",
+			method:          http.MethodGet,
+			target:          PathFollowers,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        instagram.OutputJson,
+			wantOpts: &instagram.Options{
+				Order:  instagram.OrderDesc,
+				Output: instagram.OutputJson,
+				SortBy: instagram.FieldTimestamp,
+			},
+		},
+		{
+			name:            "succeeds to retrieve following with query parameters",
+			method:          http.MethodGet,
+			target:          PathFollowing + "?limit=10&order=asc&sort-by=username&output=csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        instagram.OutputCsv,
+			wantOpts: &instagram.Options{
+				Limit:  10,
+				Order:  instagram.OrderAsc,
+				Output: instagram.OutputCsv,
+				SortBy: instagram.FieldUsername,
+			},
+		},
+		{
+			name:            "succeeds to retrieve followers page",
+			method:          http.MethodGet,
+			target:          PathFollowers + "?page=2&page-size=50",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        instagram.OutputJson,
+			wantOpts: &instagram.Options{
+				Order:    instagram.OrderDesc,
+				Output:   instagram.OutputJson,
+				Page:     2,
+				PageSize: 50,
+				SortBy:   instagram.FieldTimestamp,
+			},
+		},
+		{
+			name:            "succeeds to negotiate yaml",
+			method:          http.MethodGet,
+			target:          PathUnfollowers,
+			accept:          "text/html;q=0.9, application/yaml",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantBody:        instagram.OutputYaml,
+			wantOpts: &instagram.Options{
+				Order:  instagram.OrderDesc,
+				Output: instagram.OutputYaml,
+				SortBy: instagram.FieldTimestamp,
+			},
+		},
+		{
+			name:       "fails to negotiate unsupported media type",
+			method:     http.MethodGet,
+			target:     PathFollowers,
+			accept:     "text/html",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "fails to validate output",
+			method:     http.MethodGet,
+			target:     PathFollowers + "?output=table",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "succeeds to accept sort alias",
+			method:          http.MethodGet,
+			target:          PathUnfollowers + "?sort=username",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        instagram.OutputJson,
+			wantOpts: &instagram.Options{
+				Order:  instagram.OrderDesc,
+				Output: instagram.OutputJson,
+				SortBy: instagram.FieldUsername,
+			},
+		},
+		{
+			name:       "fails to validate sort by field",
+			method:     http.MethodGet,
+			target:     PathFollowers + "?sort-by=count",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails to parse limit",
+			method:     http.MethodGet,
+			target:     PathFollowers + "?limit=ten",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails to validate page without page size",
+			method:     http.MethodGet,
+			target:     PathFollowers + "?page=2",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails to validate order",
+			method:     http.MethodGet,
+			target:     PathFollowers + "?order=sideways",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails when file does not exist",
+			method:     http.MethodGet,
+			target:     PathFollowing,
+			err:        fs.ErrNotExist,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "fails when data is not loaded",
+			method:     http.MethodGet,
+			target:     PathFollowing,
+			err:        instagram.ErrDataNotLoaded,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "fails to retrieve followers",
+			method:     http.MethodGet,
+			target:     PathFollowers,
+			err:        fmt.Errorf("fails to retrieve followers"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "fails with unsupported method",
+			method:     http.MethodPost,
+			target:     PathFollowers,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "fails with unknown path",
+			method:     http.MethodGet,
+			target:     "/unknown",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubFollowData{
+				err: tt.err,
+			}
+			h := newHandler(stub)
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.accept != "" {
+				r.Header.Set(headerAccept, tt.accept)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantContentType, w.Header().Get(headerContentType))
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			if tt.wantOpts != nil {
+				assert.Equal(t, tt.wantOpts, stub.opts)
+			}
+		})
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+	f.On("Open", "followers").Return(func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(followersJson)), nil
+	})
+	h := NewHandler(f)
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, PathFollowers, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "username1")
+	}
+	f.AssertNumberOfCalls(t, "FindFiles", 1)
+	f.AssertNumberOfCalls(t, "Open", 1)
+}
+
+func Test_negotiateOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name:   "defaults to json without accept header",
+			accept: "",
+			want:   instagram.OutputJson,
+		},
+		{
+			name:   "defaults to json for any media type",
+			accept: "*/*",
+			want:   instagram.OutputJson,
+		},
+		{
+			name:   "selects csv",
+			accept: "text/csv",
+			want:   instagram.OutputCsv,
+		},
+		{
+			name:   "skips rejected media types",
+			accept: "application/json;q=0, text/yaml",
+			want:   instagram.OutputYaml,
+		},
+		{
+			name:   "selects highest quality media type",
+			accept: "application/json;q=0.5, text/csv;q=0.9, text/yaml;q=0.1",
+			want:   instagram.OutputCsv,
+		},
+		{
+			name:   "prefers earlier media type with equal quality",
+			accept: "text/csv;q=0.8, application/json;q=0.8",
+			want:   instagram.OutputCsv,
+		},
+		{
+			name:   "prefers implicit quality over explicit lower quality",
+			accept: "application/json;q=0.9, text/yaml",
+			want:   instagram.OutputYaml,
+		},
+		{
+			name:   "skips invalid quality",
+			accept: "text/csv;q=high, application/json;q=0.2",
+			want:   instagram.OutputJson,
+		},
+		{
+			name:   "returns empty for unsupported media types",
+			accept: "text/html, image/png",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, negotiateOutput(tt.accept))
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package stories
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+type Interface interface {
+	Cadence(opts *instagram.Options, period string) (*string, error)
+	Interactions(opts *instagram.Options) (*string, error)
+	Stickers(opts *instagram.Options) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+func (h *handler) Cadence(opts *instagram.Options, period string) (*string, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+	data, err := h.fileSystem.ReadFile(instagram.PathStories)
+	if err != nil {
+		return nil, instagram.WrapNotExist(h.fileSystem, instagram.PathStories, err)
+	}
+	stories, err := parseStories(data)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := newCadenceList(stories, period)
+	if err != nil {
+		return nil, err
+	}
+	cl.Sort(opts.SortBy, opts.Order)
+	cl.Paginate(opts)
+	return instagram.Output(cl, opts)
+}
+
+func (h *handler) Interactions(opts *instagram.Options) (*string, error) {
+	stickers, err := h.loadStickers()
+	if err != nil {
+		return nil, err
+	}
+	il := newInteractionList(stickers)
+	il.Sort(opts.SortBy, opts.Order)
+	il.Paginate(opts)
+	return instagram.Output(il, opts)
+}
+
+func (h *handler) Stickers(opts *instagram.Options) (*string, error) {
+	stickers, err := h.loadStickers()
+	if err != nil {
+		return nil, err
+	}
+	sl := &stickerList{
+		stickers: stickers,
+	}
+	sl.Sort(opts.SortBy, opts.Order)
+	sl.Paginate(opts)
+	return instagram.Output(sl, opts)
+}
+
+func (h *handler) loadStickers() ([]sticker, error) {
+	files, err := h.fileSystem.FindFiles(instagram.PathStoryStickers)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, instagram.MissingDataError(h.fileSystem, instagram.PathStoryStickers)
+	}
+	stickers := make([]sticker, 0)
+	for i := range files {
+		data, err := h.fileSystem.ReadFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseStickers(data)
+		if err != nil {
+			return nil, err
+		}
+		stickers = append(stickers, parsed...)
+	}
+	return stickers, nil
+}
+
+type storyOriginal struct {
+	Uri               string `json:"uri"`
+	CreationTimestamp int64  `json:"creation_timestamp"`
+}
+
+func parseStories(data []byte) ([]time.Time, error) {
+	jsonData := make(map[string][]storyOriginal)
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+	stories := make([]time.Time, 0, len(jsonData["ig_stories"]))
+	for i := range jsonData["ig_stories"] {
+		stories = append(stories, time.Unix(jsonData["ig_stories"][i].CreationTimestamp, 0))
+	}
+	return stories, nil
+}
+
+func parseStickers(data []byte) ([]sticker, error) {
+	events, err := activity.ParseStoryInteractions(data)
+	if err != nil {
+		return nil, err
+	}
+	stickers := make([]sticker, 0, len(events))
+	for _, event := range events {
+		stickers = append(stickers, sticker{
+			Sticker:   event.Kind,
+			Username:  event.Username,
+			Response:  event.Text,
+			Timestamp: instagram.NewTimestamp(event.Timestamp.Unix()),
+		})
+	}
+	return stickers, nil
+}
+
+type cadence struct {
+	Period  string `json:"period" yaml:"period"`
+	Stories int    `json:"stories" yaml:"stories"`
+	start   time.Time
+}
+
+type cadenceList struct {
+	periods []cadence
+}
+
+func newCadenceList(stories []time.Time, period string) (*cadenceList, error) {
+	cl := &cadenceList{
+		periods: make([]cadence, 0),
+	}
+	if len(stories) == 0 {
+		return cl, nil
+	}
+	counts := make(map[time.Time]int)
+	first, last := time.Time{}, time.Time{}
+	for i := range stories {
+		start, err := periodStart(stories[i], period)
+		if err != nil {
+			return nil, err
+		}
+		counts[start]++
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+	}
+	for start := first; !start.After(last); start = nextPeriod(start, period) {
+		cl.periods = append(cl.periods, cadence{
+			Period:  periodLabel(start, period),
+			Stories: counts[start],
+			start:   start,
+		})
+	}
+	return cl, nil
+}
+
+func validatePeriod(period string) error {
+	switch period {
+	case instagram.PeriodDay, instagram.PeriodWeek, instagram.PeriodMonth:
+		return nil
+	default:
+		return fmt.Errorf("invalid period: %s", period)
+	}
+}
+
+func periodStart(t time.Time, period string) (time.Time, error) {
+	year, month, day := t.Date()
+	switch period {
+	case instagram.PeriodDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location()), nil
+	case instagram.PeriodWeek:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location()), nil
+	case instagram.PeriodMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location()), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid period: %s", period)
+	}
+}
+
+func nextPeriod(start time.Time, period string) time.Time {
+	switch period {
+	case instagram.PeriodDay:
+		return start.AddDate(0, 0, 1)
+	case instagram.PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+func periodLabel(start time.Time, period string) string {
+	switch period {
+	case instagram.PeriodDay:
+		return start.Format(time.DateOnly)
+	case instagram.PeriodWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+func (cl *cadenceList) Data() any {
+	return cl.periods
+}
+
+func (cl *cadenceList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderPeriod,
+		instagram.TableHeaderStories,
+	}
+}
+
+func (cl *cadenceList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range cl.periods {
+		rows = append(rows, table.Row{
+			cl.periods[i].Period,
+			cl.periods[i].Stories,
+		})
+	}
+	return rows
+}
+
+func (cl *cadenceList) Sort(field string, order string) {
+	sort.SliceStable(cl.periods, func(a, b int) bool {
+		periodOne := cl.periods[a]
+		periodTwo := cl.periods[b]
+		switch field {
+		case instagram.FieldCount:
+			return periodOne.Stories < periodTwo.Stories
+		default:
+			return periodOne.start.Before(periodTwo.start)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(cl.periods)
+	}
+}
+
+func (cl *cadenceList) Paginate(opts *instagram.Options) {
+	cl.periods = instagram.Paginate(cl.periods, opts)
+}
+
+type sticker struct {
+	Sticker   string               `json:"sticker" yaml:"sticker"`
+	Username  string               `json:"username" yaml:"username"`
+	Response  string               `json:"response,omitempty" yaml:"response,omitempty"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type stickerList struct {
+	stickers []sticker
+}
+
+func (sl *stickerList) Data() any {
+	return sl.stickers
+}
+
+func (sl *stickerList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderSticker,
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderResponse,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (sl *stickerList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range sl.stickers {
+		current := sl.stickers[i]
+		rows = append(rows, table.Row{
+			current.Sticker,
+			current.Username,
+			current.Response,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (sl *stickerList) Sort(field string, order string) {
+	sort.SliceStable(sl.stickers, func(a, b int) bool {
+		stickerOne := sl.stickers[a]
+		stickerTwo := sl.stickers[b]
+		switch field {
+		case instagram.FieldUsername:
+			return stickerOne.Username < stickerTwo.Username
+		default:
+			return stickerOne.Timestamp.Time.Before(stickerTwo.Timestamp.Time)
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(sl.stickers)
+	}
+}
+
+func (sl *stickerList) Paginate(opts *instagram.Options) {
+	sl.stickers = instagram.Paginate(sl.stickers, opts)
+}
+
+type interaction struct {
+	Username        string               `json:"username" yaml:"username"`
+	ProfileUrl      string               `json:"profileUrl" yaml:"profileUrl"`
+	Interactions    int                  `json:"interactions" yaml:"interactions"`
+	LastInteraction *instagram.Timestamp `json:"lastInteraction" yaml:"lastInteraction"`
+}
+
+type interactionList struct {
+	interactions []interaction
+}
+
+func newInteractionList(stickers []sticker) *interactionList {
+	il := &interactionList{
+		interactions: make([]interaction, 0),
+	}
+	indexes := make(map[string]int)
+	for i := range stickers {
+		current := stickers[i]
+		index, ok := indexes[current.Username]
+		if !ok {
+			index = len(il.interactions)
+			indexes[current.Username] = index
+			il.interactions = append(il.interactions, interaction{
+				Username:        current.Username,
+				ProfileUrl:      fmt.Sprintf(instagram.ProfileUrlFormat, current.Username),
+				LastInteraction: current.Timestamp,
+			})
+		}
+		il.interactions[index].Interactions++
+		if current.Timestamp.After(il.interactions[index].LastInteraction.Time) {
+			il.interactions[index].LastInteraction = current.Timestamp
+		}
+	}
+	return il
+}
+
+func (il *interactionList) Data() any {
+	return il.interactions
+}
+
+func (il *interactionList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderUsername,
+		instagram.TableHeaderProfileUrl,
+		instagram.TableHeaderInteractions,
+		instagram.TableHeaderLastInteraction,
+	}
+}
+
+func (il *interactionList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range il.interactions {
+		current := il.interactions[i]
+		rows = append(rows, table.Row{
+			current.Username,
+			current.ProfileUrl,
+			current.Interactions,
+			current.LastInteraction,
+		})
+	}
+	return rows
+}
+
+func (il *interactionList) Sort(field string, order string) {
+	sort.SliceStable(il.interactions, func(a, b int) bool {
+		interactionOne := il.interactions[a]
+		interactionTwo := il.interactions[b]
+		switch field {
+		case instagram.FieldTimestamp:
+			return interactionOne.LastInteraction.Time.Before(interactionTwo.LastInteraction.Time)
+		case instagram.FieldUsername:
+			return interactionOne.Username < interactionTwo.Username
+		default:
+			return interactionOne.Interactions < interactionTwo.Interactions
+		}
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(il.interactions)
+	}
+}
+
+func (il *interactionList) Paginate(opts *instagram.Options) {
+	il.interactions = instagram.Paginate(il.interactions, opts)
+}
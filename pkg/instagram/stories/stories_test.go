@@ -0,0 +1,378 @@
+package stories
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	storiesJson = `{"ig_stories":[{"uri":"media/stories/1.mp4","creation_timestamp":1696161600},{"uri":"media/stories/2.mp4","creation_timestamp":1701388800}]}`
+	pollsJson   = `{"story_activities_polls":[{"title":"username1","string_list_data":[{"value":"yes","timestamp":1696161600}]}]}`
+	quizzesJson = `{"story_activities_quizzes":[{"title":"username1","string_list_data":[{"value":"b","timestamp":1696248000}]},{"title":"username2","string_list_data":[{"timestamp":1696334400}]}]}`
+	invalidJson = `invalid`
+)
+
+func Test_handler_Cadence(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	type args struct {
+		period string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output cadence",
+			args: args{
+				period: instagram.PeriodMonth,
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathStories).Return([]byte(storiesJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to read file",
+			args: args{
+				period: instagram.PeriodMonth,
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathStories).Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse stories",
+			args: args{
+				period: instagram.PeriodMonth,
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathStories).Return([]byte(invalidJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to validate period",
+			args: args{
+				period: "invalid",
+			},
+			expectations: nil,
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 0)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Cadence(instagram.NewEmptyOptions(), tt.args.period); (err != nil) != tt.wantErr {
+				t.Errorf("Cadence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Stickers(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output stickers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return([]string{"polls", "quizzes"}, nil)
+				f.fileSystem.On("ReadFile", "polls").Return([]byte(pollsJson), nil)
+				f.fileSystem.On("ReadFile", "quizzes").Return([]byte(quizzesJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to find files",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to find sticker category",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return([]string{}, nil)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 2)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to read file",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return([]string{"polls"}, nil)
+				f.fileSystem.On("ReadFile", "polls").Return(nil, fmt.Errorf("fails to read file"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse stickers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return([]string{"polls"}, nil)
+				f.fileSystem.On("ReadFile", "polls").Return([]byte(invalidJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Stickers(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Stickers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_handler_Interactions(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	tests := []struct {
+		name         string
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output interactions",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return([]string{"polls", "quizzes"}, nil)
+				f.fileSystem.On("ReadFile", "polls").Return([]byte(pollsJson), nil)
+				f.fileSystem.On("ReadFile", "quizzes").Return([]byte(quizzesJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to load stickers",
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathStoryStickers).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Interactions(instagram.NewEmptyOptions()); (err != nil) != tt.wantErr {
+				t.Errorf("Interactions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_parseStickers(t *testing.T) {
+	stickers, err := parseStickers([]byte(quizzesJson))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(stickers))
+	assert.Equal(t, "quizzes", stickers[0].Sticker)
+	assert.Equal(t, "username1", stickers[0].Username)
+	assert.Equal(t, "b", stickers[0].Response)
+	assert.Equal(t, "", stickers[1].Response)
+}
+
+func Test_newCadenceList(t *testing.T) {
+	type args struct {
+		stories []time.Time
+		period  string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		assertions func(t *testing.T, cl *cadenceList)
+		wantErr    bool
+	}{
+		{
+			name: "succeeds to fill empty periods",
+			args: args{
+				stories: []time.Time{
+					time.Date(2023, time.October, 10, 12, 0, 0, 0, time.Local),
+					time.Date(2023, time.October, 20, 12, 0, 0, 0, time.Local),
+					time.Date(2023, time.December, 10, 12, 0, 0, 0, time.Local),
+				},
+				period: instagram.PeriodMonth,
+			},
+			assertions: func(t *testing.T, cl *cadenceList) {
+				assert.Equal(t, []cadence{
+					{Period: "2023-10", Stories: 2, start: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.Local)},
+					{Period: "2023-11", Stories: 0, start: time.Date(2023, time.November, 1, 0, 0, 0, 0, time.Local)},
+					{Period: "2023-12", Stories: 1, start: time.Date(2023, time.December, 1, 0, 0, 0, 0, time.Local)},
+				}, cl.periods)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to group by iso week",
+			args: args{
+				stories: []time.Time{
+					time.Date(2023, time.October, 9, 12, 0, 0, 0, time.Local),
+					time.Date(2023, time.October, 15, 12, 0, 0, 0, time.Local),
+					time.Date(2023, time.October, 16, 12, 0, 0, 0, time.Local),
+				},
+				period: instagram.PeriodWeek,
+			},
+			assertions: func(t *testing.T, cl *cadenceList) {
+				assert.Equal(t, 2, len(cl.periods))
+				assert.Equal(t, "2023-W41", cl.periods[0].Period)
+				assert.Equal(t, 2, cl.periods[0].Stories)
+				assert.Equal(t, "2023-W42", cl.periods[1].Period)
+				assert.Equal(t, 1, cl.periods[1].Stories)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to group by day",
+			args: args{
+				stories: []time.Time{
+					time.Date(2023, time.October, 9, 12, 0, 0, 0, time.Local),
+					time.Date(2023, time.October, 11, 12, 0, 0, 0, time.Local),
+				},
+				period: instagram.PeriodDay,
+			},
+			assertions: func(t *testing.T, cl *cadenceList) {
+				assert.Equal(t, 3, len(cl.periods))
+				assert.Equal(t, "2023-10-10", cl.periods[1].Period)
+				assert.Equal(t, 0, cl.periods[1].Stories)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to handle no stories",
+			args: args{
+				stories: nil,
+				period:  instagram.PeriodDay,
+			},
+			assertions: func(t *testing.T, cl *cadenceList) {
+				assert.Equal(t, 0, len(cl.periods))
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to validate period",
+			args: args{
+				stories: []time.Time{time.Now()},
+				period:  "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCadenceList(tt.args.stories, tt.args.period)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newCadenceList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, got)
+			}
+		})
+	}
+}
+
+func Test_interactionList_Sort(t *testing.T) {
+	stickers, err := parseStickers([]byte(quizzesJson))
+	assert.NoError(t, err)
+	polls, err := parseStickers([]byte(pollsJson))
+	assert.NoError(t, err)
+	il := newInteractionList(append(stickers, polls...))
+	assert.Equal(t, 2, len(il.interactions))
+	il.Sort(instagram.FieldCount, instagram.OrderDesc)
+	assert.Equal(t, "username1", il.interactions[0].Username)
+	assert.Equal(t, 2, il.interactions[0].Interactions)
+	assert.Equal(t, int64(1696248000), il.interactions[0].LastInteraction.Unix())
+	il.Sort(instagram.FieldTimestamp, instagram.OrderDesc)
+	assert.Equal(t, "username2", il.interactions[0].Username)
+	il.Sort(instagram.FieldUsername, instagram.OrderAsc)
+	assert.Equal(t, "username1", il.interactions[0].Username)
+	il.Paginate(&instagram.Options{Limit: 1})
+	assert.Equal(t, 1, len(il.interactions))
+}
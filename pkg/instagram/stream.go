@@ -0,0 +1,80 @@
+package instagram
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExpectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("invalid json: expected %s but got %v", delim, token)
+	}
+	return nil
+}
+
+func DecodeArray[T any](dec *json.Decoder, visit func(value T) error) error {
+	if err := ExpectDelim(dec, '['); err != nil {
+		return err
+	}
+	return DecodeElements(dec, visit)
+}
+
+func DecodeElements[T any](dec *json.Decoder, visit func(value T) error) error {
+	for dec.More() {
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := visit(value); err != nil {
+			return err
+		}
+	}
+	return ExpectDelim(dec, ']')
+}
+
+func DecodeObject(dec *json.Decoder, visit func(key string) error) error {
+	if err := ExpectDelim(dec, '{'); err != nil {
+		return err
+	}
+	return DecodeMembers(dec, visit)
+}
+
+func DecodeMembers(dec *json.Decoder, visit func(key string) error) error {
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("invalid json: expected object key but got %v", token)
+		}
+		if err = visit(key); err != nil {
+			return err
+		}
+	}
+	return ExpectDelim(dec, '}')
+}
+
+func SkipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
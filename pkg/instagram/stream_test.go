@@ -0,0 +1,139 @@
+package instagram
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:    "succeeds to decode array",
+			input:   `[1, 2, 3]`,
+			want:    []int{1, 2, 3},
+			wantErr: false,
+		},
+		{
+			name:    "succeeds to decode empty array",
+			input:   `[]`,
+			want:    []int{},
+			wantErr: false,
+		},
+		{
+			name:    "fails to decode object",
+			input:   `{"a": 1}`,
+			want:    []int{},
+			wantErr: true,
+		},
+		{
+			name:    "fails to decode invalid element",
+			input:   `[1, "two"]`,
+			want:    []int{1},
+			wantErr: true,
+		},
+		{
+			name:    "fails to decode truncated array",
+			input:   `[1, 2`,
+			want:    []int{1, 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]int, 0)
+			err := DecodeArray(json.NewDecoder(strings.NewReader(tt.input)), func(value int) error {
+				got = append(got, value)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeArray() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "succeeds to decode object and skip nested values",
+			input:   `{"a": {"b": [1, {"c": []}]}, "d": "e", "f": [1, 2]}`,
+			want:    []string{"a", "d", "f"},
+			wantErr: false,
+		},
+		{
+			name:    "fails to decode array",
+			input:   `[]`,
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "fails to decode truncated object",
+			input:   `{"a": [1, 2`,
+			want:    []string{"a"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.input))
+			got := make([]string, 0)
+			err := DecodeObject(dec, func(key string) error {
+				got = append(got, key)
+				return SkipValue(dec)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeObject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeElements(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"a": [1, 2]}`))
+	got := make([]int, 0)
+	err := DecodeObject(dec, func(key string) error {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, json.Delim('['), token)
+		return DecodeElements(dec, func(value int) error {
+			got = append(got, value)
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestDecodeMembers(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`[{"a": 1, "b": 2}]`))
+	got := make([]string, 0)
+	err := DecodeArray(dec, func(value json.RawMessage) error {
+		inner := json.NewDecoder(strings.NewReader(string(value)))
+		if err := ExpectDelim(inner, '{'); err != nil {
+			return err
+		}
+		return DecodeMembers(inner, func(key string) error {
+			got = append(got, key)
+			return SkipValue(inner)
+		})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got)
+}
@@ -0,0 +1,205 @@
+package timeline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/cecobask/instagram-insights/pkg/instagram/followdata"
+	"github.com/cecobask/instagram-insights/pkg/instagram/searches"
+	"github.com/cecobask/instagram-insights/pkg/instagram/security"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const actorSelf = "you"
+
+type Interface interface {
+	Timeline(opts *instagram.Options, since string, until string, types string) (*string, error)
+}
+
+type handler struct {
+	fileSystem filesystem.Fs
+}
+
+func NewHandler(fileSystem filesystem.Fs) Interface {
+	return &handler{
+		fileSystem: fileSystem,
+	}
+}
+
+type loader struct {
+	category string
+	load     func(fileSystem filesystem.Fs) ([]activity.Event, error)
+}
+
+var loaders = []loader{
+	{category: activity.CategoryComment, load: activity.LoadComments},
+	{category: activity.CategoryFollower, load: followdata.LoadFollowerEvents},
+	{category: activity.CategoryFollowing, load: followdata.LoadFollowingEvents},
+	{category: activity.CategoryLike, load: activity.LoadLikes},
+	{category: activity.CategoryLogin, load: security.LoadLogins},
+	{category: activity.CategoryMessage, load: activity.LoadMessages},
+	{category: activity.CategoryPost, load: activity.LoadPosts},
+	{category: activity.CategorySearch, load: searches.LoadEvents},
+	{category: activity.CategoryStory, load: activity.LoadStoryInteractions},
+}
+
+func (h *handler) Timeline(opts *instagram.Options, since string, until string, types string) (*string, error) {
+	sinceDate, err := instagram.ParseDate(since)
+	if err != nil {
+		return nil, err
+	}
+	untilDate, err := instagram.ParseDate(until)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := ParseTypes(types)
+	if err != nil {
+		return nil, err
+	}
+	events, err := LoadEvents(h.fileSystem, categories)
+	if err != nil {
+		return nil, err
+	}
+	el := &eventList{
+		events: make([]event, 0),
+	}
+	for _, e := range events {
+		if e.Timestamp.Before(sinceDate) || (!untilDate.IsZero() && !e.Timestamp.Before(untilDate.AddDate(0, 0, 1))) {
+			continue
+		}
+		el.events = append(el.events, newEvent(e))
+	}
+	el.Sort(opts.SortBy, opts.Order)
+	el.Paginate(opts)
+	return instagram.Output(el, opts)
+}
+
+func LoadEvents(fileSystem filesystem.Fs, categories []string) ([]activity.Event, error) {
+	events := make([]activity.Event, 0)
+	for _, l := range loaders {
+		if !slices.Contains(categories, l.category) {
+			continue
+		}
+		loaded, err := l.load(fileSystem)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		events = append(events, loaded...)
+	}
+	return events, nil
+}
+
+func Types() []string {
+	return []string{
+		activity.CategoryComment,
+		activity.CategoryFollower,
+		activity.CategoryFollowing,
+		activity.CategoryLike,
+		activity.CategoryLogin,
+		activity.CategoryMessage,
+		activity.CategoryPost,
+		activity.CategorySearch,
+		activity.CategoryStory,
+	}
+}
+
+func ParseTypes(value string) ([]string, error) {
+	if value == "" {
+		return Types(), nil
+	}
+	categories := make([]string, 0)
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if !slices.Contains(Types(), t) {
+			return nil, fmt.Errorf("invalid event type: %s", t)
+		}
+		categories = append(categories, t)
+	}
+	return categories, nil
+}
+
+func newEvent(e activity.Event) event {
+	actor, target := actorSelf, e.Username
+	switch e.Category {
+	case activity.CategoryFollower:
+		actor, target = e.Username, actorSelf
+	case activity.CategoryLogin, activity.CategoryPost:
+		target = ""
+	case activity.CategoryMessage:
+		if !e.Sent {
+			actor, target = e.Username, actorSelf
+			if actor == "" {
+				actor = e.Actor
+			}
+		} else if target == "" {
+			target = e.Thread
+		}
+	}
+	return event{
+		Type:      e.Category,
+		Actor:     actor,
+		Target:    target,
+		Timestamp: &instagram.Timestamp{Time: e.Timestamp},
+	}
+}
+
+type event struct {
+	Type      string               `json:"type" yaml:"type"`
+	Actor     string               `json:"actor" yaml:"actor"`
+	Target    string               `json:"target" yaml:"target"`
+	Timestamp *instagram.Timestamp `json:"timestamp" yaml:"timestamp"`
+}
+
+type eventList struct {
+	events []event
+}
+
+func (el *eventList) Data() any {
+	return el.events
+}
+
+func (el *eventList) TableHeader() table.Row {
+	return table.Row{
+		instagram.TableHeaderType,
+		instagram.TableHeaderActor,
+		instagram.TableHeaderTarget,
+		instagram.TableHeaderTimestamp,
+	}
+}
+
+func (el *eventList) TableRows() []table.Row {
+	var rows []table.Row
+	for i := range el.events {
+		current := el.events[i]
+		rows = append(rows, table.Row{
+			current.Type,
+			current.Actor,
+			current.Target,
+			current.Timestamp,
+		})
+	}
+	return rows
+}
+
+func (el *eventList) Sort(_ string, order string) {
+	sort.SliceStable(el.events, func(a, b int) bool {
+		return el.events[a].Timestamp.Time.Before(el.events[b].Timestamp.Time)
+	})
+	if order == instagram.OrderDesc {
+		slices.Reverse(el.events)
+	}
+}
+
+func (el *eventList) Paginate(opts *instagram.Options) {
+	el.events = instagram.Paginate(el.events, opts)
+}
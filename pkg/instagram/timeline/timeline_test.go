@@ -0,0 +1,223 @@
+package timeline
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cecobask/instagram-insights/pkg/filesystem"
+	"github.com/cecobask/instagram-insights/pkg/instagram"
+	"github.com/cecobask/instagram-insights/pkg/instagram/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	likedPostsJson = `{"likes_media_likes":[{"title":"username1","string_list_data":[{"timestamp":1696161600}]},{"title":"username2","string_list_data":[{"timestamp":1696334400}]}]}`
+	followersJson  = `[{"string_list_data":[{"href":"https://www.instagram.com/username3","value":"username3","timestamp":1696248000}]}]`
+	followingJson  = `{"relationships_following":[{"string_list_data":[{"href":"https://www.instagram.com/username1","value":"username1","timestamp":1696075200}]}]}`
+)
+
+func Test_handler_Timeline(t *testing.T) {
+	type fields struct {
+		fileSystem *filesystem.MockFs
+	}
+	type args struct {
+		since string
+		until string
+		types string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		expectations func(f *fields)
+		assertions   func(t *testing.T, f *fields)
+		wantErr      bool
+	}{
+		{
+			name: "succeeds to output timeline of all types",
+			args: args{},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+				f.fileSystem.On("Open", "followers").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+				f.fileSystem.On("Open", instagram.PathFollowing).Return(io.NopCloser(strings.NewReader(followingJson)), nil)
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likedPostsJson), nil)
+				f.fileSystem.On("ReadFile", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("Open", mock.Anything).Return(nil, fs.ErrNotExist)
+				f.fileSystem.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+				f.fileSystem.On("FindFiles", mock.Anything).Return([]string{}, nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 7)
+			},
+			wantErr: false,
+		},
+		{
+			name: "succeeds to output timeline of selected types",
+			args: args{
+				since: "2023-10-01",
+				until: "2023-10-02",
+				types: "like",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("ReadFile", instagram.PathLikedPosts).Return([]byte(likedPostsJson), nil)
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 0)
+				f.fileSystem.AssertNumberOfCalls(t, "ReadFile", 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to parse since date",
+			args: args{
+				since: "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse until date",
+			args: args{
+				until: "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to parse types",
+			args: args{
+				types: "like,invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails to load events",
+			args: args{
+				types: "comment",
+			},
+			expectations: func(f *fields) {
+				f.fileSystem.On("FindFiles", instagram.PathPostComments).Return(nil, fmt.Errorf("fails to find files"))
+			},
+			assertions: func(t *testing.T, f *fields) {
+				f.fileSystem.AssertNumberOfCalls(t, "FindFiles", 1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields{
+				fileSystem: &filesystem.MockFs{},
+			}
+			h := &handler{
+				fileSystem: f.fileSystem,
+			}
+			if tt.expectations != nil {
+				tt.expectations(f)
+			}
+			if _, err := h.Timeline(instagram.NewEmptyOptions(), tt.args.since, tt.args.until, tt.args.types); (err != nil) != tt.wantErr {
+				t.Errorf("Timeline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assertions != nil {
+				tt.assertions(t, f)
+			}
+		})
+	}
+}
+
+func Test_newEvent(t *testing.T) {
+	timestamp := time.Unix(1696161600, 0)
+	tests := []struct {
+		name  string
+		event activity.Event
+		want  event
+	}{
+		{
+			name:  "follower is the actor",
+			event: activity.Event{Category: activity.CategoryFollower, Username: "username1", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryFollower, Actor: "username1", Target: actorSelf},
+		},
+		{
+			name:  "you like a username",
+			event: activity.Event{Category: activity.CategoryLike, Username: "username1", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryLike, Actor: actorSelf, Target: "username1"},
+		},
+		{
+			name:  "you message a username",
+			event: activity.Event{Category: activity.CategoryMessage, Actor: "Me", Username: "username1", Thread: "username1", Text: "hello", Sent: true, Timestamp: timestamp},
+			want:  event{Type: activity.CategoryMessage, Actor: actorSelf, Target: "username1"},
+		},
+		{
+			name:  "username messages you",
+			event: activity.Event{Category: activity.CategoryMessage, Actor: "User One", Username: "username1", Thread: "username1", Text: "hi", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryMessage, Actor: "username1", Target: actorSelf},
+		},
+		{
+			name:  "you message a group",
+			event: activity.Event{Category: activity.CategoryMessage, Actor: "Me", Thread: "group", Text: "hey", Sent: true, Timestamp: timestamp},
+			want:  event{Type: activity.CategoryMessage, Actor: actorSelf, Target: "group"},
+		},
+		{
+			name:  "group member messages you",
+			event: activity.Event{Category: activity.CategoryMessage, Actor: "User Two", Thread: "group", Text: "hey", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryMessage, Actor: "User Two", Target: actorSelf},
+		},
+		{
+			name:  "you search for an account",
+			event: activity.Event{Category: activity.CategorySearch, Username: "username1", Text: "username1", Timestamp: timestamp},
+			want:  event{Type: activity.CategorySearch, Actor: actorSelf, Target: "username1"},
+		},
+		{
+			name:  "you search for a query",
+			event: activity.Event{Category: activity.CategorySearch, Text: "golang", Timestamp: timestamp},
+			want:  event{Type: activity.CategorySearch, Actor: actorSelf, Target: ""},
+		},
+		{
+			name:  "you publish a post",
+			event: activity.Event{Category: activity.CategoryPost, Text: "caption", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryPost, Actor: actorSelf, Target: ""},
+		},
+		{
+			name:  "you log in",
+			event: activity.Event{Category: activity.CategoryLogin, Text: "127.0.0.1", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryLogin, Actor: actorSelf, Target: ""},
+		},
+		{
+			name:  "you comment on a username",
+			event: activity.Event{Category: activity.CategoryComment, Username: "username2", Text: "nice", Timestamp: timestamp},
+			want:  event{Type: activity.CategoryComment, Actor: actorSelf, Target: "username2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Timestamp = &instagram.Timestamp{Time: timestamp}
+			assert.Equal(t, tt.want, newEvent(tt.event))
+		})
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	f := &filesystem.MockFs{}
+	f.On("FindFiles", instagram.PathFollowers).Return([]string{"followers"}, nil)
+	f.On("Open", "followers").Return(io.NopCloser(strings.NewReader(followersJson)), nil)
+	f.On("Open", instagram.PathFollowing).Return(nil, fs.ErrNotExist)
+	f.On("FindFiles", instagram.PathData).Return([]string{instagram.PathData}, nil)
+	events, err := LoadEvents(f, []string{activity.CategoryFollower, activity.CategoryFollowing})
+	assert.NoError(t, err)
+	assert.Equal(t, []activity.Event{
+		{Category: activity.CategoryFollower, Username: "username3", Timestamp: time.Unix(1696248000, 0)},
+	}, events)
+}
+
+func Test_ParseTypes(t *testing.T) {
+	got, err := ParseTypes("")
+	assert.NoError(t, err)
+	assert.Equal(t, Types(), got)
+	got, err = ParseTypes("like, follower")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{activity.CategoryLike, activity.CategoryFollower}, got)
+	_, err = ParseTypes("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package instagram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Timestamp struct {
+	time.Time
+}
+
+func ParseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s", value)
+	}
+	return date, nil
+}
+
+func NewTimestamp(unix int64) *Timestamp {
+	return &Timestamp{
+		Time: time.Unix(unix, 0),
+	}
+}
+
+func (t *Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	var unixTimestamp int64
+	if err := json.Unmarshal(b, &unixTimestamp); err != nil {
+		return err
+	}
+	t.Time = time.Unix(unixTimestamp, 0)
+	return nil
+}
+
+func (t *Timestamp) MarshalYAML() (interface{}, error) {
+	return t.String(), nil
+}
+
+func (t *Timestamp) String() string {
+	return t.Format(time.RFC3339)
+}
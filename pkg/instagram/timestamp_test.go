@@ -0,0 +1,80 @@
+package instagram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	type args struct {
+		b []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "succeeds to unmarshal json",
+			args: args{
+				b: []byte("1697474963"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails to unmarshal json",
+			args: args{
+				b: []byte("invalid"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Timestamp{}
+			if err := ts.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "succeeds to parse date",
+			value:   "2023-10-01",
+			want:    time.Date(2023, time.October, 1, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "succeeds to parse empty date",
+			value:   "",
+			want:    time.Time{},
+			wantErr: false,
+		},
+		{
+			name:    "fails to parse invalid date",
+			value:   "01/10/2023",
+			want:    time.Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}